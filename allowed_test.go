@@ -0,0 +1,103 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/rbac/domains"
+	"github.com/boof/umg/rbac/policies"
+	"github.com/boof/umg/rbac/products"
+	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/services"
+)
+
+func TestListAllowedLeavesOutPartlyDeniedPatterns(t *testing.T) {
+	// migrates the database
+	adminToken(t)
+
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+
+	domain := &domains.Domain{Name: "allowed" + suffix}
+	if err := domain.Save(); err != nil {
+		t.Fatalf("unable to create domain: %v", err)
+	}
+	t.Cleanup(func() { domain.RemoveByID() })
+
+	product := &products.Product{DomainID: domain.ID, Name: "meter"}
+	if err := product.Save(); err != nil {
+		t.Fatalf("unable to create product: %v", err)
+	}
+	t.Cleanup(func() { product.RemoveByID() })
+
+	role := &roles.Role{Name: "allowed" + suffix}
+	if err := role.Save(); err != nil {
+		t.Fatalf("unable to create role: %v", err)
+	}
+	t.Cleanup(func() { db.Engine.ID(role.ID).Delete(&roles.Role{}) })
+
+	for _, policy := range []*policies.Policy{
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.DomPolicy, Actions: []string{"meter:*", "report:read"},
+			Effect: policies.Allow},
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.DomPolicy, Actions: []string{"meter:write"},
+			Effect: policies.Deny},
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.AllProdPolicy, Actions: []string{"meter:*"},
+			Effect: policies.Allow},
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.ProdPolicy, ProductID: product.ID,
+			Actions: []string{"meter:write"}, Effect: policies.Deny},
+	} {
+		if err := policy.Save(); err != nil {
+			t.Fatalf("unable to create policy: %v", err)
+		}
+		policy := policy
+		t.Cleanup(func() { policy.RemoveByID() })
+	}
+
+	user := &users.User{Username: "allowed" + suffix, Password: uuid.New().String(),
+		Email: "allowed" + suffix + "@example.com", Name: "allowed" + suffix}
+	if err := user.Save(); err != nil {
+		t.Fatalf("unable to create user: %v", err)
+	}
+	t.Cleanup(func() { user.RemoveByID() })
+
+	if err := user.AssignRole(role.ID); err != nil {
+		t.Fatalf("unable to assign role: %v", err)
+	}
+
+	ctx := context.Background()
+	rctx := services.NewRequestContext("", nil)
+
+	if !services.HasDomPerm(ctx, user, domain.Name, "meter:read", rctx) ||
+		services.HasDomPerm(ctx, user, domain.Name, "meter:write", rctx) {
+		t.Fatal("unexpected domain permissions of meter actions")
+	}
+
+	if !services.HasProdPerm(ctx, user, domain.Name, product.Name, "meter:read", rctx) ||
+		services.HasProdPerm(ctx, user, domain.Name, product.Name, "meter:write", rctx) {
+		t.Fatal("unexpected product permissions of meter actions")
+	}
+
+	domActions, allowed, err := services.ListAllowed(ctx, user, domain.Name, rctx)
+	if err != nil {
+		t.Fatalf("unable to list allowed actions: %v", err)
+	}
+
+	if strings.Join(domActions, ",") != "report:read" {
+		t.Errorf("expected only report:read on the domain, got %v", domActions)
+	}
+
+	for _, prod := range allowed {
+		for _, act := range prod.Actions {
+			if act == "meter:*" {
+				t.Errorf("partly denied meter:* is listed for %s", prod.Product)
+			}
+		}
+	}
+}
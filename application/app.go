@@ -1,16 +1,15 @@
 package application
 
 import (
-	"os"
-
 	"github.com/labstack/echo/v4"
 )
 
-func RunServer() {
+// NewServer returns the REST API server with its middlewares and routes
+func NewServer() *echo.Echo {
 	e := echo.New()
 
 	setMiddlewares(e)
 	mapRoutes(e)
 
-	e.Logger.Fatal(e.Start(os.Getenv("API_PORT")))
+	return e
 }
@@ -5,17 +5,23 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
+	"github.com/boof/umg/tracing"
 )
 
 func setMiddlewares(e *echo.Echo) {
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{Level: 5}))
 
-	e.Use(middleware.Logger())
+	e.Use(tracing.Echo)
+	e.Use(logger.Echo)
+	e.Use(metrics.Echo)
 	e.Use(middleware.Recover())
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:  []string{"*"},
 		AllowMethods:  []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete},
-		ExposeHeaders: []string{"X-Pagination-Page-Count"},
+		ExposeHeaders: []string{"X-Pagination-Page-Count", logger.HeaderRequestID},
 	}))
 }
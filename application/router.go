@@ -2,10 +2,10 @@ package application
 
 import (
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 
 	"github.com/boof/umg/auth"
 	"github.com/boof/umg/controller"
+	"github.com/boof/umg/metrics"
 	"github.com/boof/umg/settings"
 )
 
@@ -16,18 +16,25 @@ func mapRoutes(e *echo.Echo) {
 }
 
 func mapPublicRoutes(e *echo.Echo) {
-	public := e.Group(settings.BaseURL)
+	e.GET(settings.JWKSPath, controller.GetJWKS)
+	e.GET("/healthz", controller.Healthz)
+	e.GET("/readyz", controller.Readyz)
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
+	public := e.Group(settings.Conf.BaseURL)
 
 	public.POST("login", controller.Login)
+	public.POST("login/2fa", controller.LoginTwoFactor)
+	public.POST("token/refresh", controller.RefreshToken)
 	public.POST("password/reset/email", controller.ResetPasswordByEmail)
 	public.POST("password/validate", controller.ValidateResetPassToken)
 	public.POST("password/reset", controller.ChangePassword)
 }
 
 func mapUserRoutes(e *echo.Echo) {
-	user := e.Group(settings.BaseURL)
+	user := e.Group(settings.Conf.BaseURL)
 
-	user.Use(middleware.JWTWithConfig(auth.GetMiddlewareConfig()))
+	user.Use(auth.JWTHandler)
 	user.Use(auth.UserHandler)
 
 	user.GET("user/domains", controller.GetUserDomains)
@@ -35,22 +42,32 @@ func mapUserRoutes(e *echo.Echo) {
 	user.GET("user/domain/:id/products", controller.GetUserProducts)
 	user.GET("user/:id", controller.GetUser)
 	user.PUT("user", controller.UpdateUser)
+	user.POST("logout", controller.Logout)
+	user.POST("user/2fa/enroll", controller.EnrollTwoFactor)
+	user.POST("user/2fa/verify", controller.VerifyTwoFactor)
+	user.POST("user/2fa/disable", controller.DisableTwoFactor)
 }
 
 func mapAdminRoutes(e *echo.Echo) {
-	admin := e.Group(settings.BaseURL)
-	admin.Use(middleware.JWTWithConfig(auth.GetMiddlewareConfig()))
+	admin := e.Group(settings.Conf.BaseURL)
+	admin.Use(auth.JWTHandler)
 	admin.Use(auth.AdminHandler)
 
 	admin.GET("domains", controller.GetDomains)
 	admin.GET("domain/:id/products", controller.GetProducts)
+	admin.GET("domain/:id/actions", controller.GetDomainActions)
 	admin.GET("users", controller.GetUsers)
 	admin.GET("roles", controller.GetRoles)
 	admin.GET("role/:id/policies", controller.GetPolicies)
+	admin.GET("role/:id/users", controller.GetRoleMembers)
 
 	admin.GET("user/:id/email/welcome_reset", controller.SendWelcomeAndReset)
 	admin.GET("user/:id/email/history", controller.GetUserEmailHistory)
 
+	admin.GET("lockouts", controller.GetLockouts)
+	admin.GET("audit", controller.GetAuditLog)
+	admin.GET("audit/export", controller.ExportAuditLog)
+
 	admin.GET("search/users", controller.SearchUsers)
 	admin.GET("search/roles", controller.SearchRoles)
 
@@ -62,6 +79,7 @@ func mapAdminRoutes(e *echo.Echo) {
 	admin.POST("user/with-role", controller.AddUserWithRole)
 	admin.POST("domain", controller.AddDomain)
 	admin.POST("product", controller.AddProduct)
+	admin.POST("domain/:id/action", controller.AddDomainAction)
 	admin.POST("policy/domain", controller.AddDomPolicy)
 	admin.POST("policy/product", controller.AddProdPolicy)
 	admin.POST("policy/product/all", controller.AddAllProdPolicy)
@@ -73,8 +91,14 @@ func mapAdminRoutes(e *echo.Echo) {
 	admin.POST("password/change", controller.ChangeUserPassword)
 
 	admin.DELETE("policy/:id", controller.DelPolicy)
+	admin.DELETE("domain/action/:id", controller.DelDomainAction)
 	admin.DELETE("role/:id", controller.DelRole)
 	admin.DELETE("user/:id", controller.DelUser)
+	admin.DELETE("user/:id/sessions", controller.RevokeUserSessions)
+	admin.DELETE("user/:id/2fa", controller.ResetTwoFactor)
+	admin.DELETE("user/:id/lockout", controller.UnlockUser)
+	admin.DELETE("lockout/ip/:ip", controller.UnlockIP)
 
 	admin.PUT("role", controller.EditRole)
+	admin.PUT("domain/:id/algorithm", controller.SetDomainAlgorithm)
 }
@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"xorm.io/xorm"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+)
+
+const (
+	// audited entities
+	EntityUser     = "user"
+	EntityRole     = "role"
+	EntityPolicy   = "policy"
+	EntityDomain   = "domain"
+	EntityAction   = "domain_action"
+	EntityProduct  = "product"
+	EntityProperty = "property"
+	EntityExpire   = "access_expire"
+	EntityLockout  = "lockout"
+
+	// audited actions
+	ActionCreate         = "create"
+	ActionUpdate         = "update"
+	ActionDelete         = "delete"
+	ActionAssignRole     = "assign_role"
+	ActionDisallowRole   = "disallow_role"
+	ActionChangePassword = "change_password"
+	ActionRevokeSessions = "revoke_sessions"
+	ActionResetTwoFactor = "reset_two_factor"
+	ActionUnlock         = "unlock"
+)
+
+// Entry used for saving administrative mutations
+type Entry struct {
+	ID        int64     `xorm:"pk not null autoincr 'id'"`
+	ActorID   int64     `xorm:"not null index 'actor_id'"`
+	Actor     string    `xorm:"varchar(64) not null"`
+	Action    string    `xorm:"varchar(32) not null index"`
+	Entity    string    `xorm:"varchar(32) not null index"`
+	EntityID  int64     `xorm:"index 'entity_id'"`
+	Before    string    `xorm:"text"`
+	After     string    `xorm:"text"`
+	IP        string    `xorm:"varchar(64) 'ip'"`
+	CreatedAt time.Time `xorm:"created index"`
+}
+
+// Filter used for querying audit entries, zero values are ignored
+type Filter struct {
+	ActorID  int64
+	Action   string
+	Entity   string
+	EntityID int64
+	From     time.Time
+	To       time.Time
+}
+
+func (Entry) TableName() string {
+	return "audit_entry"
+}
+
+func (e *Entry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&map[string]interface{}{
+		"id":         e.ID,
+		"actor_id":   e.ActorID,
+		"actor":      e.Actor,
+		"action":     e.Action,
+		"entity":     e.Entity,
+		"entity_id":  e.EntityID,
+		"before":     rawJSON(e.Before),
+		"after":      rawJSON(e.After),
+		"ip":         e.IP,
+		"created_at": e.CreatedAt.Format(settings.DTLayout),
+	})
+}
+
+// Record saves an audit entry, before and after are states of the entity that
+// are saved as JSON and can be nil. Failures are logged and never stop the mutation
+func Record(actor *users.User, ip, action, entity string, entityID int64, before, after interface{}) {
+	entry := &Entry{
+		ActorID:  actor.ID,
+		Actor:    actor.Username,
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		Before:   toJSON(before),
+		After:    toJSON(after),
+		IP:       ip,
+	}
+
+	if _, err := db.Engine.Insert(entry); err != nil {
+		logger.Log.Error("unable to save audit entry", zap.String("action", action), zap.String("entity", entity),
+			zap.Int64("entity_id", entityID), zap.Error(err))
+	}
+}
+
+// Find returns audit entries from newest to oldest that match the filter,
+// limited to count and offset by page, with total number of matched entries
+func Find(filter Filter, count, page int64) ([]Entry, int64, error) {
+	var entries []Entry
+
+	session := filter.apply(db.Engine.NewSession())
+	defer session.Close()
+
+	total, err := session.Desc("id").Limit(int(count), int((page-1)*count)).FindAndCount(&entries)
+	return entries, total, err
+}
+
+// Iterate calls fn for each entry that matches the filter from oldest to newest
+func Iterate(filter Filter, fn func(entry *Entry) error) error {
+	session := filter.apply(db.Engine.NewSession())
+	defer session.Close()
+
+	return session.Asc("id").Iterate(new(Entry), func(i int, bean interface{}) error {
+		return fn(bean.(*Entry))
+	})
+}
+
+func (f Filter) apply(session *xorm.Session) *xorm.Session {
+	if f.ActorID > 0 {
+		session = session.And("actor_id = ?", f.ActorID)
+	}
+
+	if f.Action != "" {
+		session = session.And("action = ?", f.Action)
+	}
+
+	if f.Entity != "" {
+		session = session.And("entity = ?", f.Entity)
+	}
+
+	if f.EntityID > 0 {
+		session = session.And("entity_id = ?", f.EntityID)
+	}
+
+	if !f.From.IsZero() {
+		session = session.And("created_at >= ?", f.From)
+	}
+
+	if !f.To.IsZero() {
+		session = session.And("created_at <= ?", f.To)
+	}
+
+	return session
+}
+
+func toJSON(state interface{}) string {
+	if state == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		logger.Log.Error("unable to marshal audit state", zap.Error(err))
+		return ""
+	}
+
+	return string(data)
+}
+
+func rawJSON(state string) json.RawMessage {
+	if state == "" {
+		return json.RawMessage("null")
+	}
+
+	return json.RawMessage(state)
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/boof/umg/settings"
+)
+
+var csvHeader = []string{"id", "created_at", "actor_id", "actor", "action", "entity", "entity_id", "ip", "before", "after"}
+
+// ExportCSV writes the entries that match the filter as CSV
+func ExportCSV(filter Filter, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	err := Iterate(filter, func(e *Entry) error {
+		return writer.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.CreatedAt.Format(settings.DTLayout),
+			strconv.FormatInt(e.ActorID, 10),
+			csvCell(e.Actor),
+			csvCell(e.Action),
+			csvCell(e.Entity),
+			strconv.FormatInt(e.EntityID, 10),
+			csvCell(e.IP),
+			csvCell(e.Before),
+			csvCell(e.After),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvCell prefixes values that spreadsheets run as formulas with a quote,
+// they can come from usernames and other fields that users set
+func csvCell(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+
+	return value
+}
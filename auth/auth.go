@@ -2,20 +2,47 @@ package auth
 
 import (
 	"errors"
-	"log"
+	"net/http"
+	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/services"
+	"github.com/boof/umg/util/request"
 )
 
 const (
 	AdminUser = "ADMIN"
 )
 
+// JWTHandler forces that the request carries a valid bearer token,
+// the parsed token is stored in the `user` key of the context
+func JWTHandler(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		header := c.Request().Header.Get(echo.HeaderAuthorization)
+		if !strings.HasPrefix(header, "Bearer ") {
+			return echo.NewHTTPError(http.StatusBadRequest, "missing or malformed jwt")
+		}
+
+		token, err := parseToken(strings.TrimPrefix(header, "Bearer "))
+		if err != nil || !token.Valid {
+			return &echo.HTTPError{
+				Code:     http.StatusUnauthorized,
+				Message:  "invalid or expired jwt",
+				Internal: err,
+			}
+		}
+
+		c.Set("user", token)
+		return next(c)
+	}
+}
+
 // AdminHandler forces that the next handler function to has an admin role
 func AdminHandler(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -53,9 +80,9 @@ func Let(c echo.Context, args ...string) bool {
 	if len(args) == 1 && args[0] == AdminUser {
 		return user.IsAdmin()
 	} else if len(args) == 2 {
-		return services.HasDomPerm(user, args[0], args[1])
+		return services.HasDomPerm(c.Request().Context(), user, args[0], args[1], services.NewRequestContext(request.ClientIP(c), nil))
 	} else if len(args) == 3 {
-		return services.HasProdPerm(user, args[0], args[1], args[2])
+		return services.HasProdPerm(c.Request().Context(), user, args[0], args[1], args[2], services.NewRequestContext(request.ClientIP(c), nil))
 	} else {
 		return false
 	}
@@ -95,6 +122,10 @@ func GetUser(c echo.Context) (*users.User, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if claims.isRevoked() {
+		return nil, errors.New("token is revoked")
+	}
+
 	user, err := claims.getUser()
 	if err != nil {
 		return nil, err
@@ -102,7 +133,7 @@ func GetUser(c echo.Context) (*users.User, error) {
 
 	err = db.SetOnline(user.ID)
 	if err != nil {
-		log.Println("error while setting user status online: ", err)
+		logger.Ctx(c).Error("error while setting user status online", zap.Error(err))
 	}
 
 	return user, nil
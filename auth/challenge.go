@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+)
+
+// challengeClaims are claims of the short-lived token that is issued after a
+// successful password check when the user has two-factor authentication
+type challengeClaims struct {
+	jwt.StandardClaims
+}
+
+func (claim *challengeClaims) isChallengeToken() bool {
+	return claim.Audience == settings.Conf.JWT.ChallengeAudience
+}
+
+// CreateChallengeToken creates a two-factor challenge token for the given user
+func CreateChallengeToken(user *users.User) (string, error) {
+	cc := &challengeClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Subject:   strconv.FormatInt(user.ID, 10),
+			Audience:  settings.Conf.JWT.ChallengeAudience,
+			ExpiresAt: time.Now().Add(settings.Conf.JWT.ChallengeExpiry).Unix(),
+		},
+	}
+
+	return signToken(cc)
+}
+
+// ResolveChallenge returns the user of a challenge token if verify accepts the
+// second factor. The challenge is revoked after success or too many failures
+func ResolveChallenge(token string, verify func(user *users.User) bool) (*users.User, error) {
+	claims := &challengeClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.isChallengeToken() || claims.Id == "" || db.IsTokenDenied(claims.Id) {
+		return nil, errors.New("invalid challenge token")
+	}
+
+	user, err := getUserFromSubject(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := time.Until(time.Unix(claims.ExpiresAt, 0))
+
+	if !verify(user) {
+		failures, err := db.CountChallengeFailure(claims.Id, remaining)
+		if err != nil || failures >= int64(settings.Conf.TwoFactor.MaxAttempts) {
+			db.DenyToken(claims.Id, remaining)
+		}
+
+		return nil, errors.New("invalid two-factor code")
+	}
+
+	if err := db.DenyToken(claims.Id, remaining); err != nil {
+		logger.Log.Error("error while revoking challenge token", zap.Error(err))
+	}
+
+	return user, nil
+}
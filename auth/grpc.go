@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+)
+
+type tokenKey struct{}
+
+// UnaryServerInterceptor reads the bearer token from the `authorization`
+// metadata of gRPC calls and stores it in the context
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return handler(ctx, req)
+	}
+
+	if !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization metadata")
+	}
+
+	ctx = context.WithValue(ctx, tokenKey{}, strings.TrimPrefix(values[0], "Bearer "))
+	return handler(ctx, req)
+}
+
+// GRPCUser returns the user of the bearer token of a gRPC call, token of the
+// metadata is preferred and the token field of the request is used for old clients
+func GRPCUser(ctx context.Context, token string) (*users.User, error) {
+	if t, ok := ctx.Value(tokenKey{}).(string); ok {
+		token = t
+	}
+
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	user, err := GetUserFromToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return user, nil
+}
+
+// GRPCAdmin returns the user of the bearer token of a gRPC call only if it's an admin.
+// In certificate mode the caller is authorized by subject of its client certificate
+func GRPCAdmin(ctx context.Context, token string) (*users.User, error) {
+	if settings.Conf.GRPCTLS.AdminAuth == settings.AdminAuthCertificate {
+		return certificateAdmin(ctx)
+	}
+
+	user, err := GRPCUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() {
+		return nil, status.Error(codes.PermissionDenied, "only admins can carry out this action")
+	}
+
+	return user, nil
+}
+
+// certificateAdmin authorizes the caller by subject of its verified client certificate,
+// the returned user only names the certificate for audit entries
+func certificateAdmin(ctx context.Context) (*users.User, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing client certificate")
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing client certificate")
+	}
+
+	subject := info.State.VerifiedChains[0][0].Subject
+	for _, allowed := range settings.Conf.GRPCTLS.AdminSubjects {
+		if allowed == subject.CommonName || allowed == subject.String() {
+			return &users.User{Username: "cert:" + subject.CommonName}, nil
+		}
+	}
+
+	return nil, status.Error(codes.PermissionDenied, "client certificate can't carry out admin actions")
+}
@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/settings"
+)
+
+// signingKey is a key that used for signing or verifying tokens,
+// retired keys have no private part and only verify tokens
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+var (
+	// verification keys indexed by key id
+	keys = make(map[string]*signingKey)
+
+	// activeKey signs new tokens, nil means legacy HS256 secret
+	activeKey *signingKey
+)
+
+func init() {
+	if err := loadKeys(settings.Conf.JWT.KeysDir, settings.Conf.JWT.SigningKey); err != nil {
+		logger.Log.Fatal("unable to load jwt signing keys", zap.Error(err))
+	}
+}
+
+// loadKeys loads all PEM keys from the given directory, name of each file
+// without extension is used as key id
+func loadKeys(dir, active string) error {
+	if dir == "" {
+		logger.Log.Info("no jwt keys directory is set, using HS256 secret")
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		key, err := readKey(file)
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+
+		keys[key.id] = key
+
+		// the last private key is the default signing key
+		if key.private != nil && active == "" {
+			activeKey = key
+		}
+	}
+
+	if active != "" {
+		activeKey = keys[active]
+	}
+
+	if activeKey == nil || activeKey.private == nil {
+		return errors.New("there is no private key for signing tokens")
+	}
+
+	logger.Log.Info("jwt keys loaded", zap.Int("keys", len(keys)), zap.String("signing_key", activeKey.id))
+	return nil
+}
+
+func readKey(file string) (*signingKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM file")
+	}
+
+	key := &signingKey{id: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key.private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key.private, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed interface{}
+		if parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			signer, ok := parsed.(crypto.Signer)
+			if !ok {
+				return nil, errors.New("unsupported private key")
+			}
+			key.private = signer
+		}
+	case "PUBLIC KEY":
+		key.public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key.private != nil {
+		key.public = key.private.Public()
+	}
+
+	switch pub := key.public.(type) {
+	case *rsa.PublicKey:
+		key.method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 curve is supported for ES256")
+		}
+		key.method = jwt.SigningMethodES256
+	default:
+		return nil, errors.New("only RSA and ECDSA keys are supported")
+	}
+
+	return key, nil
+}
+
+// signToken signs the given claims with the active key
+func signToken(claims jwt.Claims) (string, error) {
+	if activeKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(settings.Conf.JWT.Secret))
+	}
+
+	token := jwt.NewWithClaims(activeKey.method, claims)
+	token.Header["kid"] = activeKey.id
+
+	return token.SignedString(activeKey.private)
+}
+
+// keyFunc returns the verification key of the given token
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	if activeKey == nil {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+
+		return []byte(settings.Conf.JWT.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key.public, nil
+}
+
+// JWKS returns the public keys as a JSON Web Key Set
+func JWKS() map[string]interface{} {
+	set := make([]map[string]string, 0)
+
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		key := keys[id]
+		jwk := map[string]string{
+			"kid": key.id,
+			"use": "sig",
+			"alg": key.method.Alg(),
+		}
+
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk["kty"] = "RSA"
+			jwk["n"] = encodeJWKInt(pub.N, 0)
+			jwk["e"] = encodeJWKInt(big.NewInt(int64(pub.E)), 0)
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			jwk["kty"] = "EC"
+			jwk["crv"] = pub.Curve.Params().Name
+			jwk["x"] = encodeJWKInt(pub.X, size)
+			jwk["y"] = encodeJWKInt(pub.Y, size)
+		}
+
+		set = append(set, jwk)
+	}
+
+	return map[string]interface{}{"keys": set}
+}
+
+// encodeJWKInt encodes a big integer in base64url, padded to size bytes
+func encodeJWKInt(n *big.Int, size int) string {
+	b := n.Bytes()
+	if len(b) < size {
+		b = append(make([]byte, size-len(b)), b...)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
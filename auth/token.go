@@ -2,23 +2,30 @@ package auth
 
 import (
 	"errors"
-	"log"
 	"strconv"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
-	"github.com/labstack/echo/v4/middleware"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/services"
 	"github.com/boof/umg/settings"
 )
 
 type tokenClaims struct {
-	Admin bool `json:"admin"`
+	Admin   bool   `json:"admin"`
+	Session string `json:"sid"`
+	Version int64  `json:"ver"`
 	jwt.StandardClaims
 }
 
 type refreshTokenClaims struct {
+	Family  string `json:"fam"`
+	Version int64  `json:"ver"`
 	jwt.StandardClaims
 }
 
@@ -30,44 +37,118 @@ func (claim *tokenClaims) isAuthToken() bool {
 	return claim.Audience == ""
 }
 
+// isRevoked indicates that the token is logged out or all tokens of its user are revoked
+func (claim *tokenClaims) isRevoked() bool {
+	if claim.Id != "" && db.IsTokenDenied(claim.Id) {
+		return true
+	}
+
+	return isRevokedVersion(claim.Subject, claim.Version)
+}
+
 func (claim *refreshTokenClaims) getUser() (*users.User, error) {
 	return getUserFromSubject(claim.Subject)
 }
 
 func (claim *refreshTokenClaims) isRefreshToken() bool {
-	return claim.Audience == settings.RefreshTokenAudience
+	return claim.Audience == settings.Conf.JWT.RefreshAudience
+}
+
+func (claim *refreshTokenClaims) isRevoked() bool {
+	return isRevokedVersion(claim.Subject, claim.Version)
 }
 
+// CreateTokens creates an access token and a refresh token for the given user,
+// the refresh token starts a new token family
 func CreateTokens(user *users.User) (string, string, error) {
+	return createTokens(user, uuid.New().String())
+}
+
+// RefreshTokens exchanges a refresh token with a new access/refresh token pair.
+// Refresh tokens are single-use, presenting a used one revokes its whole family
+func RefreshTokens(refresh string) (*users.User, string, string, error) {
+	claims := &refreshTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(refresh, claims, keyFunc)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if !claims.isRefreshToken() || claims.Id == "" || claims.Family == "" {
+		return nil, "", "", errors.New("invalid refresh token")
+	}
+
+	if claims.isRevoked() {
+		return nil, "", "", errors.New("refresh token is revoked")
+	}
+
+	if err := db.UseRefreshToken(claims.Id, claims.Family); err != nil {
+		logger.Log.Warn("refresh token rejected", zap.String("user", claims.Subject), zap.Error(err))
+		return nil, "", "", errors.New("invalid refresh token")
+	}
+
+	user, err := claims.getUser()
+	if err != nil {
+		db.RevokeTokenFamily(claims.Family)
+		return nil, "", "", err
+	}
+
+	if ok, _ := services.Expired(user.ID); ok {
+		db.RevokeTokenFamily(claims.Family)
+		return nil, "", "", errors.New("access time is expired")
+	}
+
+	token, newRefresh, err := createTokens(user, claims.Family)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return user, token, newRefresh, nil
+}
+
+func createTokens(user *users.User, family string) (string, string, error) {
+	version, err := db.GetTokenVersion(user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
 	// Set token claims
 	tc := &tokenClaims{
-		Admin: user.IsAdmin(),
+		Admin:   user.IsAdmin(),
+		Session: family,
+		Version: version,
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
 			Subject:   strconv.FormatInt(user.ID, 10),
-			ExpiresAt: time.Now().Add(settings.JWTExpiry * time.Minute).Unix(),
+			ExpiresAt: time.Now().Add(settings.Conf.JWT.Expiry).Unix(),
 		},
 	}
 
 	// set refresh token claims
 	rc := &refreshTokenClaims{
+		Family:  family,
+		Version: version,
 		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
 			Subject:   strconv.FormatInt(user.ID, 10),
-			Audience:  settings.RefreshTokenAudience,
-			ExpiresAt: time.Now().Add(settings.JWTRefreshExpiry * time.Minute).Unix(),
+			Audience:  settings.Conf.JWT.RefreshAudience,
+			ExpiresAt: time.Now().Add(settings.Conf.JWT.RefreshExpiry).Unix(),
 		},
 	}
 
-	// Create tokens
-	rawToken := jwt.NewWithClaims(jwt.SigningMethodHS256, tc)
-	rawRefresh := jwt.NewWithClaims(jwt.SigningMethodHS256, rc)
-
 	// Generate encoded tokens
-	token, err := rawToken.SignedString([]byte(settings.JWTSecret))
+	token, err := signToken(tc)
 	if err != nil {
 		return "", "", err
 	}
 
-	refresh, err := rawRefresh.SignedString([]byte(settings.JWTSecret))
+	refresh, err := signToken(rc)
+	if err != nil {
+		return "", "", err
+	}
+
+	// register refresh token so it can be used exactly once
+	err = db.SaveRefreshToken(rc.Id, family, settings.Conf.JWT.RefreshExpiry)
 	if err != nil {
 		return "", "", err
 	}
@@ -78,9 +159,7 @@ func CreateTokens(user *users.User) (string, string, error) {
 func GetUserFromToken(token string) (*users.User, error) {
 	claims := &tokenClaims{}
 
-	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (i interface{}, err error) {
-		return []byte(settings.JWTSecret), nil
-	})
+	_, err := jwt.ParseWithClaims(token, claims, keyFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +168,10 @@ func GetUserFromToken(token string) (*users.User, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if claims.isRevoked() {
+		return nil, errors.New("token is revoked")
+	}
+
 	user, err := claims.getUser()
 	if err != nil {
 		return nil, err
@@ -96,17 +179,55 @@ func GetUserFromToken(token string) (*users.User, error) {
 
 	err = db.SetOnline(user.ID)
 	if err != nil {
-		log.Println("error while setting user status online: ", err)
+		logger.Log.Error("error while setting user status online", zap.Error(err))
 	}
 
 	return user, nil
 }
 
-func GetMiddlewareConfig() middleware.JWTConfig {
-	return middleware.JWTConfig{
-		Claims:     &tokenClaims{},
-		SigningKey: []byte(settings.JWTSecret),
+// parseToken parses and verifies an access token
+func parseToken(raw string) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(raw, &tokenClaims{}, keyFunc)
+}
+
+// Logout revokes the given access token and its refresh token family
+func Logout(token *jwt.Token) error {
+	claims, ok := token.Claims.(*tokenClaims)
+	if !ok || !claims.isAuthToken() {
+		return errors.New("invalid token")
+	}
+
+	if claims.Session != "" {
+		if err := db.RevokeTokenFamily(claims.Session); err != nil {
+			return err
+		}
 	}
+
+	if claims.Id == "" {
+		return nil
+	}
+
+	return db.DenyToken(claims.Id, time.Until(time.Unix(claims.ExpiresAt, 0)))
+}
+
+// RevokeUser revokes all access and refresh tokens of the given user
+func RevokeUser(userID int64) error {
+	return db.RevokeUserTokens(userID)
+}
+
+func isRevokedVersion(subject string, version int64) bool {
+	id, err := strconv.ParseInt(subject, 10, 64)
+	if err != nil {
+		return true
+	}
+
+	current, err := db.GetTokenVersion(id)
+	if err != nil {
+		logger.Log.Error("error while getting token version", zap.Error(err))
+		return true
+	}
+
+	return version < current
 }
 
 func getUserFromSubject(subject string) (*users.User, error) {
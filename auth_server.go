@@ -2,8 +2,16 @@ package main
 
 import (
 	"context"
+	"net"
 
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/boof/umg/audit"
 	"github.com/boof/umg/auth"
+	"github.com/boof/umg/logger"
 	pb "github.com/boof/umg/proto"
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/products"
@@ -14,24 +22,113 @@ import (
 type AuthServer struct{}
 
 func (*AuthServer) HasDomPerm(ctx context.Context, req *pb.DomPermReq) (*pb.PermRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	return &pb.PermRes{Has: err == nil && services.HasDomPerm(user, req.Domain, req.Action)}, nil
+	user, err := auth.GRPCUser(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Domain == "" || req.Action == "" {
+		return nil, status.Error(codes.InvalidArgument, "domain and action are required")
+	}
+
+	rctx := services.NewRequestContext(req.Ip, req.Attributes)
+	return &pb.PermRes{Has: services.HasDomPerm(ctx, user, req.Domain, req.Action, rctx)}, nil
 }
 
 func (*AuthServer) HasProdPerm(ctx context.Context, req *pb.ProdPermReq) (*pb.PermRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	return &pb.PermRes{Has: err == nil && services.HasProdPerm(user, req.Domain, req.Product, req.Action)}, nil
+	user, err := auth.GRPCUser(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Domain == "" || req.Product == "" || req.Action == "" {
+		return nil, status.Error(codes.InvalidArgument, "domain, product and action are required")
+	}
+
+	rctx := services.NewRequestContext(req.Ip, req.Attributes)
+	return &pb.PermRes{Has: services.HasProdPerm(ctx, user, req.Domain, req.Product, req.Action, rctx)}, nil
+}
+
+func (*AuthServer) HasPropertyPerm(ctx context.Context, req *pb.PropertyPermReq) (*pb.PermRes, error) {
+	user, err := auth.GRPCUser(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "property type is required")
+	}
+
+	return &pb.PermRes{Has: services.HasPropertyPerm(ctx, user, req.Id, req.Type)}, nil
+}
+
+func (*AuthServer) BatchCheck(ctx context.Context, req *pb.BatchCheckReq) (*pb.BatchCheckRes, error) {
+	user, err := auth.GRPCUser(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Checks) > services.MaxBatchChecks {
+		return nil, status.Errorf(codes.InvalidArgument, "a batch can't have more than %d checks", services.MaxBatchChecks)
+	}
+
+	checks := make([]services.PermCheck, len(req.Checks))
+	for i, check := range req.Checks {
+		if check.PropertyType == "" && (check.Domain == "" || check.Action == "") {
+			return nil, status.Errorf(codes.InvalidArgument, "check %d should have a domain and an action or a property", i)
+		}
+
+		checks[i] = services.PermCheck{
+			Domain:       check.Domain,
+			Product:      check.Product,
+			Action:       check.Action,
+			PropertyID:   check.PropertyId,
+			PropertyType: check.PropertyType,
+		}
+	}
+
+	rctx := services.NewRequestContext(req.Ip, req.Attributes)
+	results, checkErr := services.BatchCheck(ctx, user, checks, rctx)
+	if checkErr != nil {
+		return nil, checkErr.GRPC()
+	}
+
+	return &pb.BatchCheckRes{Results: results}, nil
+}
+
+func (*AuthServer) ListAllowed(ctx context.Context, req *pb.ListAllowedReq) (*pb.ListAllowedRes, error) {
+	user, err := auth.GRPCUser(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Domain == "" {
+		return nil, status.Error(codes.InvalidArgument, "domain is required")
+	}
+
+	rctx := services.NewRequestContext(req.Ip, req.Attributes)
+	domActions, allowed, listErr := services.ListAllowed(ctx, user, req.Domain, rctx)
+	if listErr != nil {
+		return nil, listErr.GRPC()
+	}
+
+	res := &pb.ListAllowedRes{DomainActions: domActions}
+	for _, prod := range allowed {
+		res.Products = append(res.Products, &pb.AllowedProduct{Product: prod.Product, Actions: prod.Actions})
+	}
+
+	return res, nil
 }
 
 func (*AuthServer) AddProduct(ctx context.Context, req *pb.AddProdReq) (*pb.AddProdRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	if err != nil || !user.IsAdmin() {
-		return &pb.AddProdRes{Done: false, Message: "Unauthorized action, only admins can add product"}, nil
+	user, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
 	}
 
 	domain, err := (&domains.Domain{Name: req.Domain}).GetByName()
 	if err != nil {
-		return &pb.AddProdRes{Done: false, Message: "Invalid domain"}, nil
+		return nil, status.Error(codes.NotFound, "domain not found")
 	}
 
 	product := &products.Product{
@@ -40,39 +137,44 @@ func (*AuthServer) AddProduct(ctx context.Context, req *pb.AddProdReq) (*pb.AddP
 	}
 
 	if err := product.Save(); err != nil {
-		return &pb.AddProdRes{Done: false, Message: err.Error()}, nil
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	audit.Record(user, peerIP(ctx), audit.ActionCreate, audit.EntityProduct, product.ID, nil, product)
+
 	return &pb.AddProdRes{Done: true}, nil
 }
 
 func (*AuthServer) RemoveProduct(ctx context.Context, req *pb.RemProdReq) (*pb.RemProdRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	if err != nil || !user.IsAdmin() {
-		return &pb.RemProdRes{Done: false, Message: "Unauthorized action, only admins can remove product"}, nil
+	user, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
 	}
 
 	domain, err := (&domains.Domain{Name: req.Domain}).GetByName()
 	if err != nil {
-		return &pb.RemProdRes{Done: false, Message: "Invalid domain"}, nil
+		return nil, status.Error(codes.NotFound, "domain not found")
 	}
 
 	product, err := (&products.Product{DomainID: domain.ID, Name: req.Product}).GetByName()
 	if err != nil {
-		return &pb.RemProdRes{Done: false, Message: "Invalid product"}, nil
+		return nil, status.Error(codes.NotFound, "product not found")
 	}
 
 	if err := product.RemoveByID(); err != nil {
-		return &pb.RemProdRes{Done: false, Message: err.Error()}, nil
+		logger.FromContext(ctx).Error("unable to remove product", zap.Error(err))
+		return nil, status.Error(codes.Internal, "unable to remove product")
 	}
 
+	audit.Record(user, peerIP(ctx), audit.ActionDelete, audit.EntityProduct, product.ID, product, nil)
+
 	return &pb.RemProdRes{Done: true}, nil
 }
 
 func (*AuthServer) AddProperty(ctx context.Context, req *pb.AddPropertyReq) (*pb.AddPropertyRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	if err != nil || !user.IsAdmin() {
-		return &pb.AddPropertyRes{Done: false, Message: "Unauthorized action, only admins can add property"}, nil
+	user, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
 	}
 
 	property := &properties.Property{
@@ -81,33 +183,47 @@ func (*AuthServer) AddProperty(ctx context.Context, req *pb.AddPropertyReq) (*pb
 		Name:       req.Name,
 	}
 
-	err = property.Save()
-	if err != nil {
-		return &pb.AddPropertyRes{Done: false, Message: err.Error()}, nil
+	if err := property.Save(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	audit.Record(user, peerIP(ctx), audit.ActionCreate, audit.EntityProperty, property.ID, nil, property)
+
 	return &pb.AddPropertyRes{Done: true}, nil
 }
 
 func (*AuthServer) RemoveProperty(ctx context.Context, req *pb.RemPropertyReq) (*pb.RemPropertyRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	if err != nil || !user.IsAdmin() {
-		return &pb.RemPropertyRes{Done: false, Message: "Unauthorized action, only admins can remove property"}, nil
+	user, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
 	}
 
-	property, err := (&properties.Property{MeteringID: req.Id, Type: req.Type}).GetByID()
+	property, err := (&properties.Property{MeteringID: req.Id, Type: req.Type}).GetByMeteringID()
 	if err != nil {
-		return &pb.RemPropertyRes{Done: false, Message: "Invalid property"}, nil
+		return nil, status.Error(codes.NotFound, "property not found")
 	}
 
 	if err := property.RemoveByID(); err != nil {
-		return &pb.RemPropertyRes{Done: false, Message: err.Error()}, nil
+		logger.FromContext(ctx).Error("unable to remove property", zap.Error(err))
+		return nil, status.Error(codes.Internal, "unable to remove property")
 	}
 
+	audit.Record(user, peerIP(ctx), audit.ActionDelete, audit.EntityProperty, property.ID, property, nil)
+
 	return &pb.RemPropertyRes{Done: true}, nil
 }
 
-func (*AuthServer) HasPropertyPerm(ctx context.Context, req *pb.PropertyPermReq) (*pb.PermRes, error) {
-	user, err := auth.GetUserFromToken(req.Token)
-	return &pb.PermRes{Has: err == nil && services.HasPropertyPerm(user, req.Id, req.Type)}, nil
+// peerIP returns ip address of the client that sent the request
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
 }
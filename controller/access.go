@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/boof/umg/audit"
 	"github.com/boof/umg/rbac/access"
 	"github.com/boof/umg/services"
 	"github.com/boof/umg/util/response"
@@ -27,6 +28,8 @@ func AddAccessExpire(c echo.Context) error {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityExpire, expire.UserID, nil, expire)
+
 	return response.Created(c, echo.Map{"id": expire.ID})
 }
 
@@ -43,10 +46,14 @@ func EditAccessExpire(c echo.Context) error {
 
 	expire := &access.Expire{UserID: req.UserID, ExpireAt: date}
 
+	before, _ := (&access.Expire{UserID: req.UserID}).GetByUserID()
+
 	if err := services.EditExpire(expire); err != nil {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionUpdate, audit.EntityExpire, expire.UserID, before, expire)
+
 	return response.Done(c)
 }
 
@@ -56,9 +63,13 @@ func DelAccessExpire(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
+	before, _ := (&access.Expire{UserID: id}).GetByUserID()
+
 	if err := services.DelExpire(id); err != nil {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionDelete, audit.EntityExpire, id, before, nil)
+
 	return response.Done(c)
 }
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/audit"
+	"github.com/boof/umg/auth"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/services"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/util/request"
+	"github.com/boof/umg/util/response"
+)
+
+// GetAuditLog returns audit entries, filtered by query parameters
+func GetAuditLog(c echo.Context) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return response.BadReq(c, err.Error())
+	}
+
+	// pagination parameters
+	count, page := request.GetPagination(c)
+
+	entries, total, err := audit.Find(filter, count, page)
+	if err != nil {
+		logger.Ctx(c).Error("unable to get audit entries", zap.Error(err))
+		return response.InternalErr(c, "unable to get audit entries")
+	}
+
+	if entries == nil {
+		entries = make([]audit.Entry, 0)
+	}
+
+	pages := total / count
+	if total%count != 0 {
+		pages += 1
+	}
+
+	// set pagination header
+	response.SetPageCountHeader(&c, pages)
+
+	res := make([]*audit.Entry, len(entries))
+	for i := range entries {
+		res[i] = &entries[i]
+	}
+
+	return response.OK(c, res)
+}
+
+// ExportAuditLog writes audit entries, filtered by query parameters, as CSV
+func ExportAuditLog(c echo.Context) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return response.BadReq(c, err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.csv"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	if err := audit.ExportCSV(filter, c.Response()); err != nil {
+		logger.Ctx(c).Error("unable to export audit entries", zap.Error(err))
+	}
+
+	return nil
+}
+
+func auditFilter(c echo.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		Action: c.QueryParam("action"),
+		Entity: c.QueryParam("entity"),
+	}
+
+	var err error
+
+	if actor := c.QueryParam("actor_id"); actor != "" {
+		if filter.ActorID, err = strconv.ParseInt(actor, 10, 64); err != nil {
+			return filter, errors.New("invalid actor id")
+		}
+	}
+
+	if entityID := c.QueryParam("entity_id"); entityID != "" {
+		if filter.EntityID, err = strconv.ParseInt(entityID, 10, 64); err != nil {
+			return filter, errors.New("invalid entity id")
+		}
+	}
+
+	if from := c.QueryParam("from"); from != "" {
+		if filter.From, err = time.Parse(settings.DTLayout, from); err != nil {
+			return filter, errors.New("invalid from date")
+		}
+	}
+
+	if to := c.QueryParam("to"); to != "" {
+		if filter.To, err = time.Parse(settings.DTLayout, to); err != nil {
+			return filter, errors.New("invalid to date")
+		}
+	}
+
+	return filter, nil
+}
+
+// recordAudit saves an audit entry for a mutation done by the current user
+func recordAudit(c echo.Context, action, entity string, entityID int64, before, after interface{}) {
+	actor, err := auth.GetUser(c)
+	if err != nil {
+		logger.Ctx(c).Error("unable to find actor of audit entry", zap.String("action", action), zap.String("entity", entity),
+			zap.Int64("entity_id", entityID), zap.Error(err))
+		return
+	}
+
+	audit.Record(actor, request.ClientIP(c), action, entity, entityID, before, after)
+}
+
+// userState returns the given user without password for audit entries
+func userState(userID int64) interface{} {
+	user, err := services.GetUserByID(userID)
+	if err != nil {
+		return nil
+	}
+
+	return user
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+)
+
+// Healthz reports that the server is alive
+func Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, echo.Map{"status": "ok"})
+}
+
+// Readyz reports that the server can serve requests, postgres and redis should be reachable
+func Readyz(c echo.Context) error {
+	if err := db.Ping(); err != nil {
+		logger.Ctx(c).Warn("readiness check failed", zap.Error(err))
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"status": "unavailable"})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"status": "ok"})
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/boof/umg/audit"
+	"github.com/boof/umg/auth"
+	"github.com/boof/umg/services"
+	"github.com/boof/umg/util/request"
+	"github.com/boof/umg/util/response"
+)
+
+// GetLockouts returns recorded login lockouts
+func GetLockouts(c echo.Context) error {
+	count, page := request.GetPagination(c)
+	active := c.QueryParam("active") == "true"
+
+	lockouts, pages, err := services.GetLockouts(count, page, active)
+	if err != nil {
+		return err.Echo(c)
+	}
+
+	// set pagination header
+	response.SetPageCountHeader(&c, pages)
+
+	return response.OK(c, lockouts)
+}
+
+// UnlockUser removes login lock of a user
+func UnlockUser(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	admin, err := auth.GetUser(c)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	if err := services.UnlockUser(id, admin.ID); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionUnlock, audit.EntityUser, id, nil, nil)
+
+	return response.Done(c)
+}
+
+// UnlockIP removes login lock of an IP address
+func UnlockIP(c echo.Context) error {
+	ip := net.ParseIP(c.Param("ip"))
+	if ip == nil {
+		return response.BadReq(c, "invalid ip address")
+	}
+
+	admin, err := auth.GetUser(c)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	if err := services.UnlockIP(ip.String(), admin.ID); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionUnlock, audit.EntityLockout, 0, nil, echo.Map{"ip": ip.String()})
+
+	return response.Done(c)
+}
@@ -2,18 +2,24 @@ package controller
 
 import (
 	"errors"
-	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/audit"
 	"github.com/boof/umg/auth"
 	"github.com/boof/umg/db"
 	"github.com/boof/umg/email"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/rest_errors"
 	"github.com/boof/umg/services"
+	"github.com/boof/umg/settings"
 	"github.com/boof/umg/util/datetime"
+	"github.com/boof/umg/util/request"
 	"github.com/boof/umg/util/response"
 )
 
@@ -23,11 +29,61 @@ func Login(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	user, logErr := services.Login(user.Username, user.Password)
+	user, logErr := services.Login(user.Username, user.Password, request.ClientIP(c))
 	if logErr != nil {
 		return logErr.Echo(c)
 	}
 
+	// second factor is needed before issuing tokens
+	if services.TwoFactorEnabled(user.ID) {
+		challenge, err := auth.CreateChallengeToken(user)
+		if err != nil {
+			return response.InternalErr(c, "unable to create token")
+		}
+
+		return c.JSON(http.StatusOK, echo.Map{"result": echo.Map{
+			"two_factor":      true,
+			"challenge_token": challenge,
+		}})
+	}
+
+	return loginResult(c, user)
+}
+
+// LoginTwoFactor completes a login with the challenge token and a TOTP or recovery code
+func LoginTwoFactor(c echo.Context) error {
+	type Req struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+
+	req := new(Req)
+	if err := c.Bind(req); err != nil || req.ChallengeToken == "" || req.Code == "" {
+		return response.BadReq(c, "bad request")
+	}
+
+	var verifyErr rest_errors.Error
+	user, err := auth.ResolveChallenge(req.ChallengeToken, func(user *users.User) bool {
+		verifyErr = services.VerifyLoginTwoFactor(user, req.Code, request.ClientIP(c))
+		return verifyErr == nil
+	})
+	if err != nil {
+		if verifyErr != nil {
+			return verifyErr.Echo(c)
+		}
+
+		return response.Unauthorized(c, "Invalid two-factor code.")
+	}
+
+	if ok, _ := services.Expired(user.ID); ok {
+		return response.Unauthorized(c, "Your access time is expired!")
+	}
+
+	return loginResult(c, user)
+}
+
+// loginResult issues tokens for an authenticated user
+func loginResult(c echo.Context, user *users.User) error {
 	token, refresh, err := auth.CreateTokens(user)
 	if err != nil {
 		return response.InternalErr(c, "unable to create token")
@@ -36,7 +92,7 @@ func Login(c echo.Context) error {
 	user.LastLogin = datetime.NowInEasternCanada()
 	err = user.UpdateLastLogin()
 	if err != nil {
-		fmt.Println("Unable to save last login: ", err)
+		logger.Ctx(c).Error("unable to save last login", zap.Error(err))
 	}
 
 	policies, _ := services.GetPolices(user)
@@ -53,6 +109,64 @@ func Login(c echo.Context) error {
 	}})
 }
 
+// RefreshToken exchanges a refresh token with a new token pair
+func RefreshToken(c echo.Context) error {
+	type Req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	req := new(Req)
+	if err := c.Bind(req); err != nil || req.RefreshToken == "" {
+		return response.BadReq(c, "bad request")
+	}
+
+	_, token, refresh, err := auth.RefreshTokens(req.RefreshToken)
+	if err != nil {
+		return response.Unauthorized(c, "invalid refresh token")
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"result": echo.Map{
+		"token":         token,
+		"refresh_token": refresh,
+	}})
+}
+
+// Logout revokes the current access token and its refresh token
+func Logout(c echo.Context) error {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return echo.ErrUnauthorized
+	}
+
+	if err := auth.Logout(token); err != nil {
+		logger.Ctx(c).Error("unable to logout", zap.Error(err))
+		return response.InternalErr(c, "unable to logout")
+	}
+
+	return response.Done(c)
+}
+
+// RevokeUserSessions used by admin for revoking all tokens of a user
+func RevokeUserSessions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	if _, err := (&users.User{ID: id}).GetByID(); err != nil {
+		return response.NotFound(c, "user not found")
+	}
+
+	if err := auth.RevokeUser(id); err != nil {
+		logger.Ctx(c).Error("unable to revoke user sessions", zap.Error(err))
+		return response.InternalErr(c, "unable to revoke sessions")
+	}
+
+	recordAudit(c, audit.ActionRevokeSessions, audit.EntityUser, id, nil, nil)
+
+	return response.Done(c)
+}
+
 func ResetPasswordByEmail(c echo.Context) error {
 	type Req struct {
 		Email string `json:"email"`
@@ -74,11 +188,11 @@ func ResetPasswordByEmail(c echo.Context) error {
 
 	token, err := db.GenResetPassToken(user.ID, 30)
 	if err != nil {
-		log.Printf("unable to generate reset password token: %v \n", err)
+		logger.Ctx(c).Error("unable to generate reset password token", zap.Error(err))
 		return response.InternalErr(c, "internal server error")
 	}
 
-	url := "https://portal.edgecomenergy.ca/reset-password/" + token
+	url := settings.Conf.ResetPasswordURL + token
 	err = email.SendResetEmail(user.ID, user.Name, url, user.Email)
 
 	return response.Done(c)
@@ -99,11 +213,11 @@ func SendWelcomeAndReset(c echo.Context) error {
 
 	token, err := db.GenResetPassToken(user.ID, 48*60)
 	if err != nil {
-		log.Printf("unable to generate reset password token: %v \n", err)
+		logger.Ctx(c).Error("unable to generate reset password token", zap.Error(err))
 		return response.InternalErr(c, "internal server error")
 	}
 
-	url := "https://portal.edgecomenergy.ca/reset-password/" + token
+	url := settings.Conf.ResetPasswordURL + token
 	err = email.SendWelcomeAndResetEmail(user.ID, user.Name, user.Username, url, user.Email)
 
 	return response.Done(c)
@@ -161,14 +275,13 @@ func ChangePassword(c echo.Context) error {
 		return response.NotFound(c, "invalid token")
 	}
 
+	if err := services.ChangePassword(userID, req.Password); err != nil {
+		return err.Echo(c)
+	}
+
 	// revoke token
 	db.RevokeResetPassToken(req.Token)
 
-	err = (&users.User{ID: userID}).ChangePassword(req.Password)
-	if err != nil {
-		return response.NotAcceptable(c, err.Error())
-	}
-
 	return c.JSON(http.StatusOK, echo.Map{})
 }
 
@@ -189,10 +302,11 @@ func ChangeUserPassword(c echo.Context) error {
 		return response.BadReq(c, "invalid user")
 	}
 
-	err := user.ChangePassword(req.Password)
-	if err != nil {
-		return response.NotAcceptable(c, err.Error())
+	if err := services.ChangePassword(user.ID, req.Password); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionChangePassword, audit.EntityUser, user.ID, nil, nil)
+
 	return c.JSON(http.StatusOK, echo.Map{})
 }
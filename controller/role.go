@@ -5,18 +5,24 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/boof/umg/audit"
 	"github.com/boof/umg/auth"
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/policies"
 	"github.com/boof/umg/rbac/products"
 	"github.com/boof/umg/rbac/properties"
 	"github.com/boof/umg/rbac/roles"
-	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/services"
 	"github.com/boof/umg/util/request"
 	"github.com/boof/umg/util/response"
 )
 
+const (
+	// scopes of role policies
+	directScope    = "direct"
+	effectiveScope = "effective"
+)
+
 // GetProperties returns all properties
 func GetProperties(c echo.Context) error {
 	user, err := auth.GetUser(c)
@@ -132,31 +138,55 @@ func GetPolicies(c echo.Context) error {
 	}
 }
 
-func GetRawPolicies(c echo.Context) error {
+// GetRoleMembers returns users that the role is assigned to them, sorted by id
+func GetRoleMembers(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		return response.BadReq(c, "bad request")
 	}
 
-	pols, err := (&policies.Policy{RoleID: id}).GetRolePolicies()
+	count, page := request.GetPagination(c)
+
+	all, pages, getErr := services.GetUsersInRole(id, count, page)
+	if getErr != nil {
+		return getErr.Echo(c)
+	}
+
+	response.SetPageCountHeader(&c, pages)
+	return response.OK(c, all)
+}
+
+// GetRawPolicies returns policies of the role, only the direct ones unless
+// scope is effective
+func GetRawPolicies(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return response.InternalErr(c, "unable to get policies")
+		return response.BadReq(c, "bad request")
 	}
 
-	if pols == nil {
-		pols = make([]policies.Policy, 0)
+	pols, getErr := services.GetRolePolicies(id, c.QueryParam("scope") == effectiveScope)
+	if getErr != nil {
+		return getErr.Echo(c)
 	}
 
 	return response.OK(c, pols)
 }
 
+// GetNamedPolicies returns named policies of the role, including the inherited
+// ones unless scope is direct
 func GetNamedPolicies(c echo.Context) error {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		return response.BadReq(c, "bad request")
 	}
 
-	policies, err := services.GetNamedPolicies(id)
+	var policies []map[string]interface{}
+	if c.QueryParam("scope") == directScope {
+		policies, err = services.GetDirectNamedPolicies(id)
+	} else {
+		policies, err = services.GetNamedPolicies(id)
+	}
+
 	if err != nil {
 		return response.InternalErr(c, "unable to get policies")
 	}
@@ -188,18 +218,20 @@ func AddRole(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	err := role.Save()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	if err := services.AddRole(role); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityRole, role.ID, nil, role)
+
 	return response.Created(c, role)
 }
 
 func AddRoleWithPolicy(c echo.Context) error {
 	type Req struct {
-		Name     string            `json:"name"`
-		Policies []policies.Policy `json:"policies"`
+		Name      string            `json:"name"`
+		ParentIDs []int64           `json:"parent_ids"`
+		Policies  []policies.Policy `json:"policies"`
 	}
 
 	req := new(Req)
@@ -207,27 +239,13 @@ func AddRoleWithPolicy(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	role := &roles.Role{Name: req.Name}
-	if err := role.Save(); err != nil {
-		return response.BadReq(c, err.Error())
+	role := &roles.Role{Name: req.Name, ParentIDs: req.ParentIDs}
+	saved, err := services.AddRoleWithPolicies(role, req.Policies)
+	if err != nil {
+		return err.Echo(c)
 	}
 
-	saved := make([]policies.Policy, 0)
-	for _, policy := range req.Policies {
-		policy.RoleID = role.ID
-		err := policy.Save()
-		if err != nil {
-			for _, s := range saved {
-				s.RemoveByID()
-			}
-
-			services.RemoveRoleByID(role.ID)
-
-			return response.BadReq(c, err.Error())
-		}
-
-		saved = append(saved, policy)
-	}
+	recordAudit(c, audit.ActionCreate, audit.EntityRole, role.ID, nil, echo.Map{"role": role, "policies": saved})
 
 	return response.Created(c, echo.Map{"id": role.ID})
 }
@@ -243,16 +261,19 @@ func AssignRole(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	user, err := (&users.User{ID: req.UserID}).GetByID()
+	user, err := services.GetUserByID(req.UserID)
 	if err != nil {
-		return response.NotFound(c, "user not found")
+		return err.Echo(c)
 	}
 
-	err = user.AssignRole(req.RoleID)
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	before := user.RoleIDs
+
+	if err := services.AssignRole(user, req.RoleID); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionAssignRole, audit.EntityUser, user.ID, echo.Map{"role_ids": before}, echo.Map{"role_ids": user.RoleIDs})
+
 	return response.Done(c)
 }
 
@@ -267,16 +288,19 @@ func DisallowRole(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	user, err := (&users.User{ID: req.UserID}).GetByID()
+	user, err := services.GetUserByID(req.UserID)
 	if err != nil {
-		return response.NotFound(c, "user not found")
+		return err.Echo(c)
 	}
 
-	err = user.DisallowRole(req.RoleID)
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	before := user.RoleIDs
+
+	if err := services.DisallowRole(user, req.RoleID); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionDisallowRole, audit.EntityUser, user.ID, echo.Map{"role_ids": before}, echo.Map{"role_ids": user.RoleIDs})
+
 	return response.Done(c)
 }
 
@@ -286,14 +310,103 @@ func AddDomain(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	err := domain.Save()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	if err := services.AddDomain(domain); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityDomain, domain.ID, nil, domain)
+
 	return response.Created(c, domain)
 }
 
+func SetDomainAlgorithm(c echo.Context) error {
+	type Req struct {
+		Algorithm string `json:"algorithm"`
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	req := new(Req)
+	if err := c.Bind(req); err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	before, getErr := services.GetDomainByID(id)
+	if getErr != nil {
+		return getErr.Echo(c)
+	}
+
+	domain := &domains.Domain{ID: id, Name: before.Name, Algorithm: req.Algorithm}
+	if err := services.SetDomainAlgorithm(domain); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionUpdate, audit.EntityDomain, id, before, domain)
+
+	return response.Done(c)
+}
+
+// GetDomainActions returns the action catalogue of a domain
+func GetDomainActions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	actions, getErr := services.GetDomainActions(id)
+	if getErr != nil {
+		return getErr.Echo(c)
+	}
+
+	return response.OK(c, actions)
+}
+
+// AddDomainAction registers an action in the catalogue of a domain
+func AddDomainAction(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	act := new(domains.Action)
+	if err := c.Bind(act); err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	act.DomainID = id
+	if err := services.AddDomainAction(act); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionCreate, audit.EntityAction, act.ID, nil, act)
+
+	return response.Created(c, act)
+}
+
+// DelDomainAction removes an action from the catalogue of its domain
+func DelDomainAction(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	before, getErr := services.GetDomainActionByID(id)
+	if getErr != nil {
+		return getErr.Echo(c)
+	}
+
+	if err := services.RemoveDomainAction(before); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionDelete, audit.EntityAction, id, before, nil)
+
+	return response.Done(c)
+}
+
 func AddProduct(c echo.Context) error {
 	prod := new(products.Product)
 	if err := c.Bind(prod); err != nil {
@@ -305,6 +418,8 @@ func AddProduct(c echo.Context) error {
 		return response.BadReq(c, err.Error())
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityProduct, prod.ID, nil, prod)
+
 	return response.Created(c, prod)
 }
 
@@ -315,11 +430,12 @@ func AddDomPolicy(c echo.Context) error {
 	}
 
 	policy.Type = policies.DomPolicy
-	err := policy.Save()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	if err := services.AddPolicy(policy); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityPolicy, policy.ID, nil, policy)
+
 	return response.Created(c, echo.Map{"id": policy.ID})
 }
 
@@ -330,11 +446,12 @@ func AddProdPolicy(c echo.Context) error {
 	}
 
 	policy.Type = policies.ProdPolicy
-	err := policy.Save()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	if err := services.AddPolicy(policy); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityPolicy, policy.ID, nil, policy)
+
 	return response.Created(c, echo.Map{"id": policy.ID})
 }
 
@@ -345,11 +462,12 @@ func AddAllProdPolicy(c echo.Context) error {
 	}
 
 	policy.Type = policies.AllProdPolicy
-	err := policy.Save()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	if err := services.AddPolicy(policy); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityPolicy, policy.ID, nil, policy)
+
 	return response.Created(c, echo.Map{"id": policy.ID})
 }
 
@@ -359,11 +477,17 @@ func DelPolicy(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	err = (&policies.Policy{ID: int64(id)}).RemoveByID()
-	if err != nil {
-		return response.InternalErr(c, err.Error())
+	before, getErr := services.GetPolicyByID(id)
+	if getErr != nil {
+		return getErr.Echo(c)
+	}
+
+	if err := services.RemovePolicy(before); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionDelete, audit.EntityPolicy, id, before, nil)
+
 	return response.Done(c)
 }
 
@@ -373,11 +497,18 @@ func DelRole(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	err = services.RemoveRoleByID(id)
-	if err != nil {
+	var before interface{}
+	if role, err := services.GetRoleByID(id); err == nil {
+		pols, _ := policies.GetByRole(id)
+		before = echo.Map{"role": role, "policies": pols}
+	}
+
+	if err := services.RemoveRoleByID(id); err != nil {
 		return response.InternalErr(c, err.Error())
 	}
 
+	recordAudit(c, audit.ActionDelete, audit.EntityRole, id, before, nil)
+
 	return response.Done(c)
 }
 
@@ -387,10 +518,13 @@ func EditRole(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
-	err := role.Update()
-	if err != nil {
-		return response.BadReq(c, err.Error())
+	before, _ := services.GetRoleByID(role.ID)
+
+	if err := services.UpdateRole(role); err != nil {
+		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionUpdate, audit.EntityRole, role.ID, before, role)
+
 	return response.Done(c)
 }
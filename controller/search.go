@@ -1,16 +1,76 @@
 package controller
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/labstack/echo/v4"
 
+	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/services"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/util/request"
 	"github.com/boof/umg/util/response"
 )
 
+// SearchUsers returns the users that match the text and filters, ranked by
+// relevance and paginated
 func SearchUsers(c echo.Context) error {
-	text := c.QueryParam("text")
+	filter := users.SearchFilter{
+		Text:   c.QueryParam("text"),
+		Expire: c.QueryParam("expire"),
+	}
+
+	if role := c.QueryParam("role"); role != "" {
+		id, err := strconv.ParseInt(role, 10, 64)
+		if err != nil || id < 1 {
+			return response.BadReq(c, "invalid role")
+		}
+
+		filter.RoleID = id
+	}
+
+	if online := c.QueryParam("online"); online != "" {
+		value, err := strconv.ParseBool(online)
+		if err != nil {
+			return response.BadReq(c, "invalid online status")
+		}
+
+		filter.Online = &value
+	}
+
+	switch filter.Expire {
+	case "", users.ExpireActive, users.ExpireExpired, users.ExpireNone:
+	default:
+		return response.BadReq(c, "expire should be one of active, expired and none")
+	}
+
+	if from := c.QueryParam("last_login_from"); from != "" {
+		date, err := time.Parse(settings.DTLayout, from)
+		if err != nil {
+			return response.BadReq(c, "invalid last_login_from")
+		}
+
+		filter.LastLoginFrom = date
+	}
+
+	if to := c.QueryParam("last_login_to"); to != "" {
+		date, err := time.Parse(settings.DTLayout, to)
+		if err != nil {
+			return response.BadReq(c, "invalid last_login_to")
+		}
+
+		filter.LastLoginTo = date
+	}
+
+	count, page := request.GetPagination(c)
+
+	res, pages, err := services.SearchUsers(filter, count, page)
+	if err != nil {
+		return err.Echo(c)
+	}
 
-	res := services.SearchUsers(text)
+	response.SetPageCountHeader(&c, pages)
 	return response.OK(c, res)
 }
 
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/boof/umg/audit"
+	"github.com/boof/umg/auth"
+	"github.com/boof/umg/services"
+	"github.com/boof/umg/util/response"
+)
+
+// EnrollTwoFactor starts two-factor enrollment of the current user
+func EnrollTwoFactor(c echo.Context) error {
+	user, err := auth.GetUser(c)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	secret, uri, enrollErr := services.EnrollTwoFactor(user)
+	if enrollErr != nil {
+		return enrollErr.Echo(c)
+	}
+
+	return response.OK(c, echo.Map{"secret": secret, "uri": uri})
+}
+
+// VerifyTwoFactor enables two-factor authentication of the current user
+// and returns recovery codes
+func VerifyTwoFactor(c echo.Context) error {
+	type Req struct {
+		Code string `json:"code"`
+	}
+
+	req := new(Req)
+	if err := c.Bind(req); err != nil || req.Code == "" {
+		return response.BadReq(c, "bad request")
+	}
+
+	user, err := auth.GetUser(c)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	codes, enableErr := services.EnableTwoFactor(user.ID, req.Code)
+	if enableErr != nil {
+		return enableErr.Echo(c)
+	}
+
+	return response.OK(c, echo.Map{"recovery_codes": codes})
+}
+
+// DisableTwoFactor disables two-factor authentication of the current user
+func DisableTwoFactor(c echo.Context) error {
+	type Req struct {
+		Code string `json:"code"`
+	}
+
+	req := new(Req)
+	if err := c.Bind(req); err != nil || req.Code == "" {
+		return response.BadReq(c, "bad request")
+	}
+
+	user, err := auth.GetUser(c)
+	if err != nil {
+		return echo.ErrUnauthorized
+	}
+
+	if err := services.DisableTwoFactor(user.ID, req.Code); err != nil {
+		return err.Echo(c)
+	}
+
+	return response.Done(c)
+}
+
+// ResetTwoFactor used by admin for removing two-factor authentication of a user
+func ResetTwoFactor(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return response.BadReq(c, "bad request")
+	}
+
+	if err := services.ResetTwoFactor(id); err != nil {
+		return err.Echo(c)
+	}
+
+	recordAudit(c, audit.ActionResetTwoFactor, audit.EntityUser, id, nil, nil)
+
+	return response.Done(c)
+}
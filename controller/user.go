@@ -7,6 +7,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/boof/umg/audit"
 	"github.com/boof/umg/auth"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/services"
@@ -124,6 +125,8 @@ func AddUser(c echo.Context) error {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityUser, user.ID, nil, userState(user.ID))
+
 	return response.OK(c, echo.Map{"id": user.ID})
 }
 
@@ -151,6 +154,8 @@ func AddUserWithRole(c echo.Context) error {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionCreate, audit.EntityUser, user.ID, nil, userState(user.ID))
+
 	return response.Created(c, echo.Map{"id": user.ID})
 }
 
@@ -164,11 +169,15 @@ func UpdateUser(c echo.Context) error {
 		return echo.ErrUnauthorized
 	}
 
+	before := userState(user.ID)
+
 	err := services.UpdateUser(user)
 	if err != nil {
 		return err.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionUpdate, audit.EntityUser, user.ID, before, userState(user.ID))
+
 	return response.Done(c)
 }
 
@@ -178,10 +187,14 @@ func DelUser(c echo.Context) error {
 		return response.BadReq(c, "bad request")
 	}
 
+	before := userState(id)
+
 	delErr := services.DelUser(id)
 	if delErr != nil {
 		return delErr.Echo(c)
 	}
 
+	recordAudit(c, audit.ActionDelete, audit.EntityUser, id, before, nil)
+
 	return response.Done(c)
 }
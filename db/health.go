@@ -0,0 +1,28 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/go-redis/redis/v7"
+)
+
+// Ping checks the connections of postgres and all redis databases
+func Ping() error {
+	if err := Engine.Ping(); err != nil {
+		return fmt.Errorf("postgres: %v", err)
+	}
+
+	clients := map[string]*redis.Client{
+		"redis":              redisClient,
+		"redis online users": onlineUsers,
+		"redis permissions":  permissions,
+	}
+
+	for name, client := range clients {
+		if err := client.Ping().Err(); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return nil
+}
@@ -2,15 +2,15 @@ package db
 
 import (
 	"fmt"
-	"log"
-	"os"
 
 	"github.com/go-redis/redis/v7"
+	"go.uber.org/zap"
 	"xorm.io/xorm"
 
 	// postgres driver to be used by xorm
 	_ "github.com/lib/pq"
 
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/settings"
 )
 
@@ -19,26 +19,28 @@ var (
 
 	redisClient *redis.Client
 	onlineUsers *redis.Client
+	permissions *redis.Client
 )
 
 func init() {
 	createEngine()
 	createRedisClient()
 	createOnlineUsers()
+	createPermissions()
 }
 
 // createEngine creates xorm postgres Engine
 func createEngine() {
 	eng, err := xorm.NewEngine(settings.DriverName, GetDataSourceName())
 	if err != nil {
-		log.Fatalf("error while creating new database Engine: %v", err)
+		logger.Log.Fatal("error while creating new database engine", zap.Error(err))
 	}
 
 	err = eng.Ping()
 	if err != nil {
-		log.Fatalf("error while connecting database: %v", err)
+		logger.Log.Fatal("error while connecting database", zap.Error(err))
 	} else {
-		log.Println("postgres Engine created successfully")
+		logger.Log.Info("postgres engine created successfully")
 	}
 
 	Engine = eng
@@ -46,44 +48,53 @@ func createEngine() {
 
 func createRedisClient() {
 	redisClient = redis.NewClient(&redis.Options{
-		Addr:     "redis:6379",
-		Password: "", // no password set
-		DB:       0,  // use default DB
+		Addr:     settings.Conf.Redis.Addr,
+		Password: settings.Conf.Redis.Password,
+		DB:       settings.Conf.Redis.DB,
 	})
 
 	_, err := redisClient.Ping().Result()
 	if err != nil {
-		log.Fatalf("Error while creating redis client: %v \n", err)
+		logger.Log.Fatal("error while creating redis client", zap.Error(err))
 	} else {
-		log.Println("Redis client created successfully!")
+		logger.Log.Info("redis client created successfully")
 	}
 }
 
 func createOnlineUsers() {
 	onlineUsers = redis.NewClient(&redis.Options{
-		Addr:     "redis:6379",
-		Password: "", // no password set
-		DB:       1,  // use default DB
+		Addr:     settings.Conf.Redis.Addr,
+		Password: settings.Conf.Redis.Password,
+		DB:       settings.Conf.Redis.OnlineUsersDB,
 	})
 
 	_, err := onlineUsers.Ping().Result()
 	if err != nil {
-		log.Fatalf("Error while creating redis client for online users: %v \n", err)
+		logger.Log.Fatal("error while creating redis client for online users", zap.Error(err))
 	} else {
-		log.Println("Redis client for online users created successfully!")
+		logger.Log.Info("redis client for online users created successfully")
 	}
 }
 
-// GetDataSourceName returns data source name
-func GetDataSourceName() string {
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		os.Getenv(settings.DBHost), os.Getenv(settings.DBPort),
-		os.Getenv(settings.PostgresUser), os.Getenv(settings.PostgresPass), os.Getenv(settings.PostgresDB))
-}
+func createPermissions() {
+	permissions = redis.NewClient(&redis.Options{
+		Addr:     settings.Conf.Redis.Addr,
+		Password: settings.Conf.Redis.Password,
+		DB:       settings.Conf.Redis.PermissionsDB,
+	})
 
-func Sync(table interface{}) {
-	err := Engine.Sync(table)
+	_, err := permissions.Ping().Result()
 	if err != nil {
-		log.Fatalf("error while syncing tables: %v", err)
+		logger.Log.Fatal("error while creating redis client for permissions", zap.Error(err))
+	} else {
+		logger.Log.Info("redis client for permissions created successfully")
 	}
 }
+
+// GetDataSourceName returns data source name
+func GetDataSourceName() string {
+	conf := settings.Conf.Database
+
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		conf.Host, conf.Port, conf.User, conf.Password, conf.Name, conf.SSLMode)
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v7"
+)
+
+const (
+	loginFailurePrefix = "login_failure:"
+	loginLockPrefix    = "login_lock:"
+)
+
+// RecordLoginFailure increases and returns the number of failed logins of the
+// given key, counter is kept for the window after the last failure
+func RecordLoginFailure(key string, window time.Duration) (int64, error) {
+	pipe := redisClient.TxPipeline()
+	count := pipe.Incr(loginFailurePrefix + key)
+	pipe.Expire(loginFailurePrefix+key, window)
+
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
+
+// ClearLoginFailures removes failed logins counter and lock of the given key
+func ClearLoginFailures(key string) error {
+	_, err := redisClient.Del(loginFailurePrefix+key, loginLockPrefix+key).Result()
+	return err
+}
+
+// LockLogin locks login of the given key for the duration
+func LockLogin(key string, duration time.Duration) error {
+	_, err := redisClient.Set(loginLockPrefix+key, "locked", duration).Result()
+	return err
+}
+
+// LoginLockedFor returns the remaining lock time of the given key
+func LoginLockedFor(key string) (time.Duration, error) {
+	ttl, err := redisClient.TTL(loginLockPrefix + key).Result()
+	if err == redis.Nil || ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, err
+}
@@ -70,3 +70,23 @@ func IsOnline(userID int64) bool {
 
 	return true
 }
+
+// CountOnline returns count of the online users
+func CountOnline() (int64, error) {
+	return onlineUsers.DBSize().Result()
+}
+
+// GetOnline returns ids of the online users
+func GetOnline() ([]int64, error) {
+	ids := make([]int64, 0)
+
+	iter := onlineUsers.Scan(0, "*", 1000).Iterator()
+	for iter.Next() {
+		id, err := strconv.ParseInt(iter.Val(), 10, 64)
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, iter.Err()
+}
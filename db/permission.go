@@ -0,0 +1,101 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/logger"
+)
+
+const (
+	permissionsPrefix           = "permissions:"
+	permissionsGenerationPrefix = "permissions_generation:"
+
+	// changes whenever permissions of all users are invalidated
+	permissionsEpochKey = "permissions_epoch"
+)
+
+// setPermissionsScript caches permissions only if the generation is still the
+// one they were compiled at, so a concurrent invalidation is never overwritten
+var setPermissionsScript = redis.NewScript(`
+if (redis.call("GET", KEYS[2]) or "") .. ":" .. (redis.call("GET", KEYS[3]) or "") ~= ARGV[1] then
+	return 0
+end
+
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// GetPermissions returns the compiled permissions of the user, found is false
+// when they are not cached
+func GetPermissions(userID int64) (data []byte, found bool, err error) {
+	data, err = permissions.Get(permissionsKey(userID)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+// PermissionsGeneration returns the generation of the user's permissions, it
+// changes whenever permissions of the user or all users are invalidated. It
+// should be read before compiling the permissions that are cached
+func PermissionsGeneration(userID int64) (string, error) {
+	values, err := permissions.MGet(permissionsEpochKey, permissionsGenerationKey(userID)).Result()
+	if err != nil {
+		return "", err
+	}
+
+	generation := make([]string, len(values))
+	for i, v := range values {
+		if str, ok := v.(string); ok {
+			generation[i] = str
+		}
+	}
+
+	return strings.Join(generation, ":"), nil
+}
+
+// SetPermissions caches the compiled permissions of the user, nothing is
+// cached if permissions were invalidated after reading the generation
+func SetPermissions(userID int64, generation string, data []byte, ttl time.Duration) error {
+	keys := []string{permissionsKey(userID), permissionsEpochKey, permissionsGenerationKey(userID)}
+	return setPermissionsScript.Run(permissions, keys, generation, data, ttl.Milliseconds()).Err()
+}
+
+// InvalidatePermissions removes the cached permissions of the user
+func InvalidatePermissions(userID int64) {
+	pipe := permissions.TxPipeline()
+	pipe.Incr(permissionsGenerationKey(userID))
+	pipe.Del(permissionsKey(userID))
+
+	if _, err := pipe.Exec(); err != nil {
+		logger.Log.Error("unable to invalidate permissions", zap.Int64("user_id", userID), zap.Error(err))
+	}
+}
+
+// InvalidateAllPermissions removes the cached permissions of all users,
+// permissions have a dedicated redis database so it's flushed
+func InvalidateAllPermissions() {
+	pipe := permissions.TxPipeline()
+	pipe.FlushDB()
+	pipe.Set(permissionsEpochKey, strconv.FormatInt(time.Now().UnixNano(), 10), 0)
+
+	if _, err := pipe.Exec(); err != nil {
+		logger.Log.Error("unable to invalidate permissions", zap.Error(err))
+	}
+}
+
+func permissionsKey(userID int64) string {
+	return permissionsPrefix + strconv.FormatInt(userID, 10)
+}
+
+func permissionsGenerationKey(userID int64) string {
+	return permissionsGenerationPrefix + strconv.FormatInt(userID, 10)
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v7"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/logger"
+)
+
+const (
+	refreshTokenPrefix = "refresh:"
+	tokenFamilyPrefix  = "family:"
+)
+
+// SaveRefreshToken registers a new unused refresh token in the given token family
+// and extends the lifetime of the family
+func SaveRefreshToken(tokenID, family string, duration time.Duration) error {
+	pipe := redisClient.TxPipeline()
+	pipe.Set(refreshTokenPrefix+tokenID, family, duration)
+	pipe.Set(tokenFamilyPrefix+family, "active", duration)
+
+	_, err := pipe.Exec()
+	return err
+}
+
+// UseRefreshToken consumes a refresh token, each refresh token can be used only once.
+// If a consumed token is presented again the whole token family is revoked
+func UseRefreshToken(tokenID, family string) error {
+	active, err := redisClient.Exists(tokenFamilyPrefix + family).Result()
+	if err != nil {
+		return err
+	}
+
+	if active == 0 {
+		return fmt.Errorf("token family is revoked")
+	}
+
+	deleted, err := redisClient.Del(refreshTokenPrefix + tokenID).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		// reuse detected, somebody else may own this token
+		if err := RevokeTokenFamily(family); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("refresh token reused")
+	}
+
+	return nil
+}
+
+// RevokeTokenFamily revokes all refresh tokens of the given family
+func RevokeTokenFamily(family string) error {
+	_, err := redisClient.Del(tokenFamilyPrefix + family).Result()
+	return err
+}
+
+const (
+	tokenVersionPrefix = "token_version:"
+	deniedTokenPrefix  = "denied:"
+)
+
+// GetTokenVersion returns current token version of the given user,
+// tokens that issued with an older version are revoked
+func GetTokenVersion(userID int64) (int64, error) {
+	version, err := redisClient.Get(tokenVersionPrefix + strconv.FormatInt(userID, 10)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return version, err
+}
+
+// RevokeUserTokens revokes all tokens of the given user
+func RevokeUserTokens(userID int64) error {
+	_, err := redisClient.Incr(tokenVersionPrefix + strconv.FormatInt(userID, 10)).Result()
+	return err
+}
+
+// DenyToken adds a token to the deny list until it expires
+func DenyToken(tokenID string, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+
+	_, err := redisClient.Set(deniedTokenPrefix+tokenID, "revoked", duration).Result()
+	return err
+}
+
+// IsTokenDenied indicates that the given token is revoked or not
+func IsTokenDenied(tokenID string) bool {
+	denied, err := redisClient.Exists(deniedTokenPrefix + tokenID).Result()
+	if err != nil {
+		logger.Log.Error("error while checking token deny list", zap.Error(err))
+		return true
+	}
+
+	return denied > 0
+}
+
+const challengeFailurePrefix = "challenge_failure:"
+
+// CountChallengeFailure increases and returns the number of wrong codes
+// that are sent for a two-factor challenge token
+func CountChallengeFailure(tokenID string, duration time.Duration) (int64, error) {
+	pipe := redisClient.TxPipeline()
+	count := pipe.Incr(challengeFailurePrefix + tokenID)
+	pipe.Expire(challengeFailurePrefix+tokenID, duration)
+
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+
+	return count.Val(), nil
+}
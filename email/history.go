@@ -18,10 +18,6 @@ type History struct {
 	Date   time.Time `xorm:"not null 'date'"`
 }
 
-func init() {
-	db.Sync(new(History))
-}
-
 func (h *History) Save() rest_errors.Error {
 	if err := h.InsertValidate(); err != nil {
 		return err
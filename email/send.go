@@ -4,34 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
-	"log"
-	"os"
 	"text/template"
 
+	"go.uber.org/zap"
 	"gopkg.in/mail.v2"
-)
-
-// SMTPConfig used for saving SMTP mail server config
-type SMTPConfig struct {
-	MailServer string
-	Port       string
-	Address    string
-	Password   string
-}
 
-// GetSupportConfig this function gets support mail server config from environment variables
-func GetSupportConfig() SMTPConfig {
-	return SMTPConfig{
-		MailServer: os.Getenv("MAIL_SERVER"),
-		Port:       os.Getenv("MAIL_SERVER_PORT"),
-		Address:    os.Getenv("SUPPORT_MAIL_ADDRESS"),
-		Password:   os.Getenv("SUPPORT_MAIL_PASS"),
-	}
-}
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
+	"github.com/boof/umg/settings"
+)
 
 // SendSupport send HTML email from support mail server
 func SendSupport(subject, msgPlain, msgHTML, email string) error {
-	conf := GetSupportConfig()
+	conf := settings.Conf.Mail
 
 	m := mail.NewMessage()
 	m.SetHeader("From", conf.Address)
@@ -44,10 +29,13 @@ func SendSupport(subject, msgPlain, msgHTML, email string) error {
 	// m.SetAddressHeader("Cc", "support@edgecom.io", "Edgecom Support")
 	// m.Attach("/home/Alex/lolcat.jpg")
 
-	d := mail.NewDialer(conf.MailServer, 587, conf.Address, conf.Password)
+	d := mail.NewDialer(conf.Server, conf.Port, conf.Address, conf.Password)
 	d.StartTLSPolicy = mail.MandatoryStartTLS
 
-	return d.DialAndSend(m)
+	err := d.DialAndSend(m)
+	metrics.Email(err)
+
+	return err
 }
 
 // SendWelcome sends a welcome email to the new registered user
@@ -105,7 +93,7 @@ func SendResetEmail(userID int64, userName, link, email string) error {
 	}
 
 	if err := AddHistory(userID, "Reset Password"); err != nil {
-		log.Println("error while saving email history: ", err)
+		logger.Log.Error("error while saving email history", zap.Error(err))
 	}
 
 	return nil
@@ -141,7 +129,7 @@ func SendWelcomeAndResetEmail(userID int64, userName, username, link, email stri
 	}
 
 	if err := AddHistory(userID, "Welcome"); err != nil {
-		log.Println("error while saving email history: ", err)
+		logger.Log.Error("error while saving email history", zap.Error(err))
 	}
 
 	return nil
@@ -1,11 +1,12 @@
 package initialize
 
 import (
-	"log"
 	"os"
 
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
 
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/products"
 )
@@ -44,19 +45,19 @@ func loadConfig() (*Config, error) {
 func createDomains() {
 	config, err := loadConfig()
 	if err != nil {
-		log.Fatalf("unable to load config file: %v", err)
+		logger.Log.Fatal("unable to load config file", zap.Error(err))
 	}
 
 	for _, domain := range config.Domains {
 		dom, err := createDomain(domain.Name)
 		if err != nil {
-			log.Fatalf("unable to create %s Domain: %v", domain.Name, err)
+			logger.Log.Fatal("unable to create domain", zap.String("domain", domain.Name), zap.Error(err))
 		}
 
 		for _, product := range domain.Products {
 			_, err = createProduct(dom.ID, product.Name)
 			if err != nil {
-				log.Fatalf("unable to create %s product: %v", product.Name, err)
+				logger.Log.Fatal("unable to create product", zap.String("product", product.Name), zap.Error(err))
 			}
 		}
 	}
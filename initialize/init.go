@@ -1,6 +1,8 @@
 package initialize
 
-func init() {
+// Run creates the admin user and the default domains if they don't exist,
+// the schema must be migrated before
+func Run() {
 	createAdmin()
 	createDomains()
 }
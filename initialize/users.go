@@ -1,8 +1,9 @@
 package initialize
 
 import (
-	"log"
+	"go.uber.org/zap"
 
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rbac/users"
 )
@@ -10,17 +11,17 @@ import (
 func createAdmin() {
 	role, err := createRole("admin")
 	if err != nil {
-		log.Fatalf("unable to create admin role: %v", err)
+		logger.Log.Fatal("unable to create admin role", zap.Error(err))
 	}
 
 	admin, err := createUser("admin", "pass", "admin@edgecomenergy.ca")
 	if err != nil {
-		log.Fatalf("unable to create admin user: %v", err)
+		logger.Log.Fatal("unable to create admin user", zap.Error(err))
 	}
 
 	err = admin.AssignRole(role.ID)
 	if err != nil {
-		log.Fatalf("unable to assign a admin role to admin user: %v", err)
+		logger.Log.Fatal("unable to assign a admin role to admin user", zap.Error(err))
 	}
 }
 
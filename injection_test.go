@@ -0,0 +1,252 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/boof/umg/application"
+	"github.com/boof/umg/auth"
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/migrations"
+	pb "github.com/boof/umg/proto"
+	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+)
+
+// These tests need postgres and redis of the configuration, run them by:
+//
+//	go test -tags integration -run Injection .
+
+// common payloads of SQL injection, the sleeping ones show blind injections
+var payloads = []string{
+	`' OR '1'='1`,
+	`' OR 1=1 --`,
+	`'; DROP TABLE "user"; --`,
+	`id; DROP TABLE role`,
+	`id DESC; DELETE FROM user_roles`,
+	`name, (SELECT pg_sleep(3))`,
+	`'; SELECT pg_sleep(3); --`,
+	`1) UNION SELECT id, username, password FROM "user" --`,
+	`%' AND 1=1 --`,
+	`\'; SELECT 1; --`,
+	`1; UPDATE "user" SET password = ''`,
+	`-1`,
+	`99999999999999999999`,
+}
+
+// REST endpoints with a %s placeholder for the payload
+var restEndpoints = []string{
+	"users?format=simple&sort=%s",
+	"users?format=simple&order=%s",
+	"users?format=with-role&sort=%s",
+	"users?format=simple&count=%s",
+	"users?format=simple&page=%s",
+	"roles?sort=%s",
+	"roles?order=%s",
+	"roles?format=with-policy&sort=%s",
+	"search/users?text=%s",
+	"search/users?text=a&page=%s",
+	"search/users?role=%s",
+	"search/users?online=%s",
+	"search/users?expire=%s",
+	"search/users?last_login_from=%s&last_login_to=%s",
+	"search/roles?text=%s",
+	"role/1/users?page=%s",
+	"role/1/policies?format=raw&scope=%s",
+	"domains?sort=%s",
+	"domains?order=%s",
+	"domains?format=%s",
+	"domain/%s/products",
+	"domain/%s/actions",
+	"user/%s/email/history",
+	"audit?action=%s",
+	"audit?entity=%s&actor_id=%s",
+	"audit/export?action=%s",
+	"audit/export?entity=%s&actor_id=%s&entity_id=%s",
+	"audit/export?from=%s&to=%s",
+	"lockouts?active=%s",
+	"user/domains?id=%s",
+	"user/domain/%s/products",
+	"user/properties?domain=%s",
+}
+
+// numeric payloads of the gRPC requests
+var intPayloads = []int64{-1, 0, 1 << 40}
+
+func TestInjectionREST(t *testing.T) {
+	_, token := adminToken(t)
+	before := rowCounts(t)
+	e := application.NewServer()
+
+	for _, endpoint := range restEndpoints {
+		for _, payload := range payloads {
+			escaped := url.QueryEscape(payload)
+			target := settings.Conf.BaseURL + strings.ReplaceAll(endpoint, "%s", escaped)
+
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			start := time.Now()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code >= http.StatusInternalServerError {
+				t.Errorf("GET %s: status %d", target, rec.Code)
+			}
+
+			if time.Since(start) > 2*time.Second {
+				t.Errorf("GET %s: took %v", target, time.Since(start))
+			}
+		}
+	}
+
+	assertRowCounts(t, before)
+}
+
+func TestInjectionSearchMatchesLiterally(t *testing.T) {
+	admin, token := adminToken(t)
+	e := application.NewServer()
+
+	for _, payload := range []string{`' OR '1'='1`, `%' OR '1'='1`, `%`} {
+		target := settings.Conf.BaseURL + "search/users?text=" + url.QueryEscape(payload)
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var res []users.RoleUser
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("GET %s: unable to decode response %q: %v", target, rec.Body.String(), err)
+		}
+
+		// the temporary admin doesn't have any of the searched texts
+		for _, user := range res {
+			if user.ID == admin.ID {
+				t.Errorf("GET %s: matched %s", target, admin.Username)
+			}
+		}
+	}
+}
+
+func TestInjectionGRPC(t *testing.T) {
+	_, token := adminToken(t)
+	before := rowCounts(t)
+	ctx := context.Background()
+	s := &ManagementServer{}
+
+	for _, payload := range payloads {
+		list := &pb.ListReq{Token: token, Sort: payload, Order: payload}
+
+		if _, err := s.ListUsers(ctx, list); err != nil {
+			t.Errorf("ListUsers with %q: %v", payload, err)
+		}
+
+		if _, err := s.ListRoles(ctx, list); err != nil {
+			t.Errorf("ListRoles with %q: %v", payload, err)
+		}
+	}
+
+	if _, err := s.ListDomains(ctx, &pb.TokenReq{Token: token}); err != nil {
+		t.Errorf("ListDomains: %v", err)
+	}
+
+	// unknown ids may be rejected, but not with an internal error
+	for _, id := range intPayloads {
+		checks := map[string]error{}
+
+		_, checks["ListRoleUsers"] = s.ListRoleUsers(ctx, &pb.ListRoleUsersReq{Token: token, RoleId: id, Count: id, Page: id})
+		_, checks["ListDomainActions"] = s.ListDomainActions(ctx, &pb.EntityReq{Token: token, Id: id})
+		_, checks["ListPolicies"] = s.ListPolicies(ctx, &pb.ListPoliciesReq{Token: token, RoleId: id})
+		_, checks["ListPolicies effective"] = s.ListPolicies(ctx, &pb.ListPoliciesReq{Token: token, RoleId: id, Effective: true})
+
+		for method, err := range checks {
+			if code := status.Code(err); code == codes.Internal || code == codes.Unknown {
+				t.Errorf("%s with %d: %v", method, id, err)
+			}
+		}
+	}
+
+	assertRowCounts(t, before)
+}
+
+// adminToken returns a temporary admin user with its access token
+func adminToken(t *testing.T) (*users.User, string) {
+	t.Helper()
+
+	m, err := migrations.New(db.Engine.DB().DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("unable to migrate: %v", err)
+	}
+
+	role, err := (&roles.Role{Name: "admin"}).GetByName()
+	if err != nil {
+		role = &roles.Role{Name: "admin"}
+		if err := role.Save(); err != nil {
+			t.Fatalf("unable to create admin role: %v", err)
+		}
+	}
+
+	name := "injection" + strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+	user := &users.User{Username: name, Password: uuid.New().String(), Email: name + "@example.com", Name: name}
+	if err := user.Save(); err != nil {
+		t.Fatalf("unable to create user: %v", err)
+	}
+	t.Cleanup(func() { user.RemoveByID() })
+
+	if err := user.AssignRole(role.ID); err != nil {
+		t.Fatalf("unable to assign admin role: %v", err)
+	}
+
+	token, _, err := auth.CreateTokens(user)
+	if err != nil {
+		t.Fatalf("unable to create token: %v", err)
+	}
+
+	return user, token
+}
+
+func rowCounts(t *testing.T) map[string]int64 {
+	t.Helper()
+
+	counts := make(map[string]int64)
+	for _, table := range []string{`"user"`, "role", "user_roles", "policy"} {
+		var count int64
+		if err := db.Engine.DB().QueryRow("SELECT count(*) FROM " + table).Scan(&count); err != nil {
+			t.Fatalf("unable to count %s: %v", table, err)
+		}
+
+		counts[table] = count
+	}
+
+	return counts
+}
+
+func assertRowCounts(t *testing.T, before map[string]int64) {
+	t.Helper()
+
+	for table, count := range rowCounts(t) {
+		if count != before[table] {
+			t.Errorf("rows of %s changed from %d to %d", table, before[table], count)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor gives each call a request id like the Echo middleware,
+// the id is sent back in the response header metadata and every call is logged
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	key := strings.ToLower(HeaderRequestID)
+
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 && len(values[0]) <= 128 {
+			id = values[0]
+		}
+	}
+
+	if id == "" {
+		id = NewRequestID()
+	}
+
+	ctx = WithRequestID(ctx, id)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", id))
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(key, id)); err != nil {
+		FromContext(ctx).Warn("unable to set request id header", zap.Error(err))
+	}
+
+	res, err := handler(ctx, req)
+
+	fields := []zap.Field{
+		zap.String("method", info.FullMethod),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	FromContext(ctx).Info("grpc request", fields...)
+
+	return res, err
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/util/request"
+)
+
+// Echo is a middleware that gives each request a request id, the id of the
+// client is kept when it sends one. The id is echoed back in the response
+// header and every request is logged when it's done.
+func Echo(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		req := c.Request()
+
+		id := req.Header.Get(HeaderRequestID)
+		if id == "" || len(id) > 128 {
+			id = NewRequestID()
+		}
+
+		ctx := WithRequestID(req.Context(), id)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", id))
+
+		c.SetRequest(req.WithContext(ctx))
+		c.Response().Header().Set(HeaderRequestID, id)
+
+		err := next(c)
+		if err != nil {
+			c.Error(err)
+		}
+
+		res := c.Response()
+		fields := []zap.Field{
+			zap.String("method", req.Method),
+			zap.String("uri", req.RequestURI),
+			zap.String("route", c.Path()),
+			zap.Int("status", res.Status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("remote_ip", request.ClientIP(c)),
+			zap.Int64("bytes_out", res.Size),
+		}
+
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+
+		FromContext(ctx).Info("http request", fields...)
+
+		return nil
+	}
+}
+
+// Ctx returns the logger of the request
+func Ctx(c echo.Context) *zap.Logger {
+	return FromContext(c.Request().Context())
+}
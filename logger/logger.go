@@ -0,0 +1,80 @@
+// Package logger provides the structured JSON logger of the service and
+// request scoped loggers that carry the request id and trace of a call
+package logger
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/boof/umg/settings"
+)
+
+// HeaderRequestID is the HTTP header and gRPC metadata key of request ids
+const HeaderRequestID = "X-Request-ID"
+
+// Log is the logger of the service, FromContext should be used when
+// there is a request context
+var Log *zap.Logger
+
+type requestIDKey struct{}
+
+func init() {
+	l, err := New(settings.Conf.Log.Level)
+	if err != nil {
+		log.Fatalf("unable to create logger: %v", err)
+	}
+
+	Log = l
+}
+
+// New returns a JSON logger with the given minimum level
+func New(level string) (*zap.Logger, error) {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, err
+	}
+
+	conf := zap.NewProductionConfig()
+	conf.Level = zap.NewAtomicLevelAt(lvl)
+	conf.EncoderConfig.TimeKey = "time"
+	conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	return conf.Build()
+}
+
+// NewRequestID returns a new random request id
+func NewRequestID() string {
+	return uuid.New().String()
+}
+
+// WithRequestID returns a copy of the context with the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id of the context, it's empty when
+// the context doesn't belong to a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the logger with the request id and the trace of the context
+func FromContext(ctx context.Context) *zap.Logger {
+	l := Log
+
+	if id := RequestID(ctx); id != "" {
+		l = l.With(zap.String("request_id", id))
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		l = l.With(zap.String("trace_id", span.TraceID().String()), zap.String("span_id", span.SpanID().String()))
+	}
+
+	return l
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New("debug"); err != nil {
+		t.Errorf("unexpected error for debug level: %v", err)
+	}
+
+	if _, err := New("verbose"); err == nil {
+		t.Error("expected invalid level to be rejected")
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	ctx := context.Background()
+	if id := RequestID(ctx); id != "" {
+		t.Errorf("expected empty request id, got %q", id)
+	}
+
+	ctx = WithRequestID(ctx, "abc")
+	if id := RequestID(ctx); id != "abc" {
+		t.Errorf("expected request id abc, got %q", id)
+	}
+
+	if NewRequestID() == NewRequestID() {
+		t.Error("expected unique request ids")
+	}
+}
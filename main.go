@@ -1,51 +1,157 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"context"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/boof/umg/application"
-	_ "github.com/boof/umg/initialize"
+	"github.com/boof/umg/auth"
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/initialize"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
 	pb "github.com/boof/umg/proto"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/tracing"
 )
 
+// interval of checking postgres and redis for the gRPC health service
+const healthCheckInterval = 10 * time.Second
+
 func main() {
-	go application.RunServer()
+	defer logger.Log.Sync()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
 
-	lis, err := net.Listen("tcp", "0.0.0.0:50053")
+	checkMigrations()
+	initialize.Run()
+
+	shutdownTracing, err := tracing.Init(settings.Conf.Tracing)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logger.Log.Fatal("failed to set up tracing", zap.Error(err))
+	}
+
+	e := application.NewServer()
+	go func() {
+		if err := e.Start(settings.Conf.APIAddr); err != nil && err != http.ErrServerClosed {
+			logger.Log.Fatal("failed to serve REST API", zap.Error(err))
+		}
+	}()
+
+	lis, err := net.Listen("tcp", settings.Conf.GRPCAddr)
+	if err != nil {
+		logger.Log.Fatal("failed to listen", zap.Error(err))
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			tracing.UnaryServerInterceptor,
+			logger.UnaryServerInterceptor,
+			metrics.UnaryServerInterceptor,
+			auth.UnaryServerInterceptor,
+		),
+	}
+
+	creds, err := transportCredentials(settings.Conf.GRPCTLS)
+	if err != nil {
+		logger.Log.Fatal("failed to load grpc tls credentials", zap.Error(err))
+	} else if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+		logger.Log.Info("gRPC TLS enabled")
 	}
 
-	var opts []grpc.ServerOption
 	s := grpc.NewServer(opts...)
 	pb.RegisterAuthServiceServer(s, &AuthServer{})
+	pb.RegisterManagementServiceServer(s, &ManagementServer{})
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go watchHealth(healthServer)
 
 	// Register reflection service on gRPC AuthServer
 	reflection.Register(s)
 
 	go func() {
-		fmt.Println("Starting gRPC Server...")
+		logger.Log.Info("starting gRPC server", zap.String("addr", settings.Conf.GRPCAddr))
 		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
+			logger.Log.Fatal("failed to serve gRPC", zap.Error(err))
 		}
 	}()
 
-	// Wait for Control C to exit
+	// Wait for Control C or termination to exit
 	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 
 	// Block until a signal is received
 	<-ch
-	fmt.Println("Stopping the parksideServer")
-	s.Stop()
-	fmt.Println("Closing the listener")
-	lis.Close()
-	fmt.Println("End of Program")
+	logger.Log.Info("stopping the servers")
+	healthServer.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), settings.Conf.ShutdownTimeout)
+	defer cancel()
+
+	restStopped := make(chan struct{})
+	go func() {
+		if err := e.Shutdown(ctx); err != nil {
+			logger.Log.Warn("REST API server didn't stop gracefully", zap.Error(err))
+		}
+		close(restStopped)
+	}()
+
+	stopGRPC(ctx, s)
+	<-restStopped
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Log.Warn("unable to flush spans", zap.Error(err))
+	}
+
+	logger.Log.Info("servers stopped")
+}
+
+// watchHealth updates serving status of the gRPC health service by checking
+// postgres and redis periodically
+func watchHealth(healthServer *health.Server) {
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if err := db.Ping(); err != nil {
+			logger.Log.Warn("health check failed", zap.Error(err))
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus("umg.AuthService", status)
+		healthServer.SetServingStatus("umg.ManagementService", status)
+
+		time.Sleep(healthCheckInterval)
+	}
+}
+
+// stopGRPC waits for in-flight calls to finish, the server is stopped
+// abruptly when the context is done first
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+	}
 }
@@ -0,0 +1,686 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/boof/umg/audit"
+	"github.com/boof/umg/auth"
+	pb "github.com/boof/umg/proto"
+	"github.com/boof/umg/rbac/access"
+	"github.com/boof/umg/rbac/domains"
+	"github.com/boof/umg/rbac/policies"
+	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/services"
+	"github.com/boof/umg/settings"
+)
+
+// maximum page size of the list methods
+const maxPageSize = 20
+
+type ManagementServer struct{}
+
+func (*ManagementServer) GetUser(ctx context.Context, req *pb.EntityReq) (*pb.User, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	user, err := services.GetUserByID(req.Id)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	return pbUser(user), nil
+}
+
+func (*ManagementServer) ListUsers(ctx context.Context, req *pb.ListReq) (*pb.ListUsersRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	count, page, order := listParams(req)
+
+	sortBy := req.Sort
+	if !services.IsValidSort(sortBy) {
+		sortBy = services.ID
+	}
+
+	all, pages, err := services.GetUsers(count, page, order, sortBy)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListUsersRes{PageCount: pages}
+	for i := range all {
+		res.Users = append(res.Users, pbUser(&all[i]))
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) AddUser(ctx context.Context, req *pb.AddUserReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	var expireAt *time.Time
+	if req.ExpireAt != "" {
+		date, err := time.Parse(settings.DTLayout, req.ExpireAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid expiry date")
+		}
+
+		expireAt = &date
+	}
+
+	user := toUser(req.User)
+	if addErr := services.AddUserWithRole(user, req.SendEmail, expireAt); addErr != nil {
+		return nil, addErr.GRPC()
+	}
+
+	after, _ := services.GetUserByID(user.ID)
+	audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityUser, user.ID, nil, after)
+
+	return &pb.DoneRes{Done: true, Id: user.ID}, nil
+}
+
+func (*ManagementServer) UpdateUser(ctx context.Context, req *pb.UpdateUserReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	before, getErr := services.GetUserByID(req.User.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	user := toUser(req.User)
+	if updateErr := services.UpdateUser(user); updateErr != nil {
+		return nil, updateErr.GRPC()
+	}
+
+	after, _ := services.GetUserByID(user.ID)
+	audit.Record(actor, peerIP(ctx), audit.ActionUpdate, audit.EntityUser, user.ID, before, after)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) RemoveUser(ctx context.Context, req *pb.EntityReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	before, getErr := services.GetUserByID(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	if delErr := services.DelUser(req.Id); delErr != nil {
+		return nil, delErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDelete, audit.EntityUser, req.Id, before, nil)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) GetRole(ctx context.Context, req *pb.EntityReq) (*pb.Role, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	role, err := services.GetRoleByID(req.Id)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	return pbRole(role), nil
+}
+
+func (*ManagementServer) ListRoles(ctx context.Context, req *pb.ListReq) (*pb.ListRolesRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	count, page, order := listParams(req)
+
+	sortBy := req.Sort
+	if !roles.IsValidSort(sortBy) {
+		sortBy = roles.ID
+	}
+
+	all, err := services.GetAllRoles(count, page, order, sortBy)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListRolesRes{PageCount: roles.Pages(count)}
+	for i := range all {
+		res.Roles = append(res.Roles, pbRole(&all[i]))
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) AddRole(ctx context.Context, req *pb.AddRoleReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Role == nil {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	role := &roles.Role{Name: req.Role.Name, ParentIDs: req.Role.ParentIds}
+
+	pols := make([]policies.Policy, 0, len(req.Policies))
+	for _, p := range req.Policies {
+		pols = append(pols, *toPolicy(p))
+	}
+
+	saved, addErr := services.AddRoleWithPolicies(role, pols)
+	if addErr != nil {
+		return nil, addErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityRole, role.ID, nil, map[string]interface{}{"role": role, "policies": saved})
+
+	return &pb.DoneRes{Done: true, Id: role.ID}, nil
+}
+
+func (*ManagementServer) UpdateRole(ctx context.Context, req *pb.UpdateRoleReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Role == nil {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	before, getErr := services.GetRoleByID(req.Role.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	role := &roles.Role{ID: req.Role.Id, Name: req.Role.Name, ParentIDs: req.Role.ParentIds}
+	if req.ClearParents {
+		role.ParentIDs = make([]int64, 0)
+	}
+
+	if updateErr := services.UpdateRole(role); updateErr != nil {
+		return nil, updateErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionUpdate, audit.EntityRole, role.ID, before, role)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) RemoveRole(ctx context.Context, req *pb.EntityReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	role, getErr := services.GetRoleByID(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	pols, _ := policies.GetByRole(req.Id)
+
+	if delErr := services.RemoveRoleByID(req.Id); delErr != nil {
+		return nil, delErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDelete, audit.EntityRole, req.Id, map[string]interface{}{"role": role, "policies": pols}, nil)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) AssignRole(ctx context.Context, req *pb.RoleAssignmentReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, getErr := services.GetUserByID(req.UserId)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	before := user.RoleIDs
+
+	if assignErr := services.AssignRole(user, req.RoleId); assignErr != nil {
+		return nil, assignErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionAssignRole, audit.EntityUser, user.ID,
+		map[string]interface{}{"role_ids": before}, map[string]interface{}{"role_ids": user.RoleIDs})
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) DisallowRole(ctx context.Context, req *pb.RoleAssignmentReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, getErr := services.GetUserByID(req.UserId)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	before := user.RoleIDs
+
+	if disallowErr := services.DisallowRole(user, req.RoleId); disallowErr != nil {
+		return nil, disallowErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDisallowRole, audit.EntityUser, user.ID,
+		map[string]interface{}{"role_ids": before}, map[string]interface{}{"role_ids": user.RoleIDs})
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) ListRoleUsers(ctx context.Context, req *pb.ListRoleUsersReq) (*pb.ListUsersRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	count, page, _ := listParams(&pb.ListReq{Count: req.Count, Page: req.Page})
+
+	all, pages, err := services.GetUsersInRole(req.RoleId, count, page)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListUsersRes{PageCount: pages}
+	for i := range all {
+		res.Users = append(res.Users, pbUser(&all[i]))
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesReq) (*pb.ListPoliciesRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	if _, err := services.GetRoleByID(req.RoleId); err != nil {
+		return nil, err.GRPC()
+	}
+
+	pols, err := services.GetRolePolicies(req.RoleId, req.Effective)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListPoliciesRes{}
+	for i := range pols {
+		res.Policies = append(res.Policies, pbPolicy(&pols[i]))
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) AddPolicy(ctx context.Context, req *pb.AddPolicyReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Policy == nil {
+		return nil, status.Error(codes.InvalidArgument, "policy is required")
+	}
+
+	policy := toPolicy(req.Policy)
+	if addErr := services.AddPolicy(policy); addErr != nil {
+		return nil, addErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityPolicy, policy.ID, nil, policy)
+
+	return &pb.DoneRes{Done: true, Id: policy.ID}, nil
+}
+
+func (*ManagementServer) RemovePolicy(ctx context.Context, req *pb.EntityReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	before, getErr := services.GetPolicyByID(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	if delErr := services.RemovePolicy(before); delErr != nil {
+		return nil, delErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDelete, audit.EntityPolicy, req.Id, before, nil)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) GetDomain(ctx context.Context, req *pb.EntityReq) (*pb.Domain, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	domain, err := services.GetDomainByID(req.Id)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	return pbDomain(domain), nil
+}
+
+func (*ManagementServer) ListDomains(ctx context.Context, req *pb.TokenReq) (*pb.ListDomainsRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	all, err := services.GetAllDomains()
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListDomainsRes{}
+	for i := range all {
+		res.Domains = append(res.Domains, pbDomain(&all[i]))
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) AddDomain(ctx context.Context, req *pb.AddDomainReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Domain == nil {
+		return nil, status.Error(codes.InvalidArgument, "domain is required")
+	}
+
+	domain := &domains.Domain{Name: req.Domain.Name, Algorithm: req.Domain.Algorithm}
+	if addErr := services.AddDomain(domain); addErr != nil {
+		return nil, addErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityDomain, domain.ID, nil, domain)
+
+	return &pb.DoneRes{Done: true, Id: domain.ID}, nil
+}
+
+func (*ManagementServer) SetDomainAlgorithm(ctx context.Context, req *pb.SetDomainAlgorithmReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	before, getErr := services.GetDomainByID(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	domain := &domains.Domain{ID: req.Id, Name: before.Name, Algorithm: req.Algorithm}
+	if setErr := services.SetDomainAlgorithm(domain); setErr != nil {
+		return nil, setErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionUpdate, audit.EntityDomain, req.Id, before, domain)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) ListDomainActions(ctx context.Context, req *pb.EntityReq) (*pb.ListDomainActionsRes, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	actions, err := services.GetDomainActions(req.Id)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	res := &pb.ListDomainActionsRes{}
+	for _, act := range actions {
+		res.Actions = append(res.Actions, &pb.DomainAction{
+			Id:          act.ID,
+			DomainId:    act.DomainID,
+			Name:        act.Name,
+			Description: act.Description,
+		})
+	}
+
+	return res, nil
+}
+
+func (*ManagementServer) AddDomainAction(ctx context.Context, req *pb.AddDomainActionReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Action == nil {
+		return nil, status.Error(codes.InvalidArgument, "action is required")
+	}
+
+	act := &domains.Action{
+		DomainID:    req.Action.DomainId,
+		Name:        req.Action.Name,
+		Description: req.Action.Description,
+	}
+
+	if addErr := services.AddDomainAction(act); addErr != nil {
+		return nil, addErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityAction, act.ID, nil, act)
+
+	return &pb.DoneRes{Done: true, Id: act.ID}, nil
+}
+
+func (*ManagementServer) RemoveDomainAction(ctx context.Context, req *pb.EntityReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	before, getErr := services.GetDomainActionByID(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	if delErr := services.RemoveDomainAction(before); delErr != nil {
+		return nil, delErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDelete, audit.EntityAction, req.Id, before, nil)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) GetAccessExpire(ctx context.Context, req *pb.EntityReq) (*pb.AccessExpire, error) {
+	if _, err := auth.GRPCAdmin(ctx, req.Token); err != nil {
+		return nil, err
+	}
+
+	expire, err := services.GetExpire(req.Id)
+	if err != nil {
+		return nil, err.GRPC()
+	}
+
+	return &pb.AccessExpire{UserId: expire.UserID, ExpireAt: expire.ExpireAt.Format(settings.DTLayout)}, nil
+}
+
+// SetAccessExpire adds or changes access expire of the user
+func (*ManagementServer) SetAccessExpire(ctx context.Context, req *pb.SetAccessExpireReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Expire == nil {
+		return nil, status.Error(codes.InvalidArgument, "expire is required")
+	}
+
+	date, err := time.Parse(settings.DTLayout, req.Expire.ExpireAt)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid date")
+	}
+
+	before, _ := services.GetExpire(req.Expire.UserId)
+
+	expire := &access.Expire{UserID: req.Expire.UserId, ExpireAt: date}
+	if before == nil {
+		if addErr := services.AddExpire(expire); addErr != nil {
+			return nil, addErr.GRPC()
+		}
+
+		audit.Record(actor, peerIP(ctx), audit.ActionCreate, audit.EntityExpire, expire.UserID, nil, expire)
+	} else {
+		if editErr := services.EditExpire(expire); editErr != nil {
+			return nil, editErr.GRPC()
+		}
+
+		audit.Record(actor, peerIP(ctx), audit.ActionUpdate, audit.EntityExpire, expire.UserID, before, expire)
+	}
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+func (*ManagementServer) RemoveAccessExpire(ctx context.Context, req *pb.EntityReq) (*pb.DoneRes, error) {
+	actor, err := auth.GRPCAdmin(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	before, getErr := services.GetExpire(req.Id)
+	if getErr != nil {
+		return nil, getErr.GRPC()
+	}
+
+	if delErr := services.DelExpire(req.Id); delErr != nil {
+		return nil, delErr.GRPC()
+	}
+
+	audit.Record(actor, peerIP(ctx), audit.ActionDelete, audit.EntityExpire, req.Id, before, nil)
+
+	return &pb.DoneRes{Done: true}, nil
+}
+
+// listParams returns page size, page and order of the list request,
+// invalid values are replaced by the defaults of the REST API
+func listParams(req *pb.ListReq) (count, page int64, order string) {
+	count, page, order = req.Count, req.Page, req.Order
+
+	if count < 1 || count > maxPageSize {
+		count = maxPageSize
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	if order != services.Desc {
+		order = services.Asc
+	}
+
+	return
+}
+
+func pbUser(user *users.User) *pb.User {
+	return &pb.User{
+		Id:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Name:      user.Name,
+		Company:   user.Company,
+		Website:   user.Website,
+		Address1:  user.Address1,
+		Address2:  user.Address2,
+		Phone1:    user.Phone1,
+		Phone2:    user.Phone2,
+		Fax1:      user.Fax1,
+		Fax2:      user.Fax2,
+		RoleIds:   user.RoleIDs,
+		LastLogin: user.LastLogin.Format(settings.DTLayout),
+	}
+}
+
+func toUser(user *pb.User) *users.User {
+	return &users.User{
+		ID:       user.Id,
+		Username: user.Username,
+		Password: user.Password,
+		Email:    user.Email,
+		Name:     user.Name,
+		Company:  user.Company,
+		Website:  user.Website,
+		Address1: user.Address1,
+		Address2: user.Address2,
+		Phone1:   user.Phone1,
+		Phone2:   user.Phone2,
+		Fax1:     user.Fax1,
+		Fax2:     user.Fax2,
+		RoleIDs:  user.RoleIds,
+	}
+}
+
+func pbRole(role *roles.Role) *pb.Role {
+	return &pb.Role{Id: role.ID, Name: role.Name, ParentIds: role.ParentIDs}
+}
+
+func pbPolicy(policy *policies.Policy) *pb.Policy {
+	return &pb.Policy{
+		Id:         policy.ID,
+		RoleId:     policy.RoleID,
+		Type:       policy.Type,
+		Actions:    policy.Actions,
+		Properties: policy.Properties,
+		ProductId:  policy.ProductID,
+		DomainId:   policy.DomainID,
+		Effect:     policy.Effect,
+		Condition:  policy.Condition,
+	}
+}
+
+func toPolicy(policy *pb.Policy) *policies.Policy {
+	return &policies.Policy{
+		RoleID:     policy.RoleId,
+		Type:       policy.Type,
+		Actions:    policy.Actions,
+		Properties: policy.Properties,
+		ProductID:  policy.ProductId,
+		DomainID:   policy.DomainId,
+		Effect:     policy.Effect,
+		Condition:  policy.Condition,
+	}
+}
+
+func pbDomain(domain *domains.Domain) *pb.Domain {
+	return &pb.Domain{Id: domain.ID, Name: domain.Name, Algorithm: domain.Algorithm}
+}
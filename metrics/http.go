@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Echo is a middleware that counts the requests and records their latency,
+// requests are labeled by the route pattern to keep ids out of the labels
+func Echo(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		err := next(c)
+		if err != nil {
+			// let echo write the error so its status is known
+			c.Error(err)
+		}
+
+		route := c.Path()
+		if route == "" {
+			route = "unknown"
+		}
+
+		method := c.Request().Method
+		code := strconv.Itoa(c.Response().Status)
+
+		httpRequests.WithLabelValues(method, route, code).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+// Package metrics collects Prometheus metrics of the REST API, gRPC methods,
+// logins, permission checks and emails
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+)
+
+const namespace = "umg"
+
+const (
+	// kinds of permission checks
+	DomainCheck   = "domain"
+	ProductCheck  = "product"
+	PropertyCheck = "property"
+	BatchCheck    = "batch"
+
+	// results of logins, checks and emails
+	Success = "success"
+	Failure = "failure"
+	Locked  = "locked"
+	Expired = "expired"
+	Allow   = "allow"
+	Deny    = "deny"
+	Hit     = "hit"
+	Miss    = "miss"
+
+	// label of the domains that the user doesn't have any policy in
+	OtherDomain = "other"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Count of the handled HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Count of the handled gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Latency of the gRPC calls by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Count of the login attempts by result.",
+	}, []string{"result"})
+
+	permissionChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "permission_checks_total",
+		Help:      "Count of the permission checks by kind, domain and result.",
+	}, []string{"kind", "domain", "result"})
+
+	permissionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "permission_check_duration_seconds",
+		Help:      "Latency of the permission checks by kind, including the permissions lookup.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"kind"})
+
+	permissionCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "permission_cache_requests_total",
+		Help:      "Count of the permissions cache lookups by result.",
+	}, []string{"result"})
+
+	emails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_total",
+		Help:      "Count of the sent emails by result.",
+	}, []string{"result"})
+
+	_ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "online_users",
+		Help:      "Count of the users that are online.",
+	}, onlineUsers)
+)
+
+// Handler returns the HTTP handler of the metrics endpoint
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Login counts a login attempt with the given result
+func Login(result string) {
+	logins.WithLabelValues(result).Inc()
+}
+
+// PermissionCheck counts the result of a permission check on the domain
+func PermissionCheck(kind, domain string, allowed bool) {
+	result := Deny
+	if allowed {
+		result = Allow
+	}
+
+	permissionChecks.WithLabelValues(kind, domain, result).Inc()
+}
+
+// ObservePermissionCheck records latency of a permission check started at start
+func ObservePermissionCheck(kind string, start time.Time) {
+	permissionDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
+
+// PermissionCache counts a lookup of the permissions cache
+func PermissionCache(hit bool) {
+	result := Miss
+	if hit {
+		result = Hit
+	}
+
+	permissionCache.WithLabelValues(result).Inc()
+}
+
+// Email counts a sent email, err is the result of sending it
+func Email(err error) {
+	result := Success
+	if err != nil {
+		result = Failure
+	}
+
+	emails.WithLabelValues(result).Inc()
+}
+
+func onlineUsers() float64 {
+	count, err := db.CountOnline()
+	if err != nil {
+		logger.Log.Error("unable to count online users", zap.Error(err))
+		return 0
+	}
+
+	return float64(count)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/migrations"
+)
+
+const migrateUsage = "usage: umg migrate up | down [steps] | status"
+
+// runMigrate runs the `migrate` subcommand with the given arguments
+func runMigrate(args []string) {
+	m, err := migrations.New(db.Engine.DB().DB)
+	if err != nil {
+		logger.Log.Fatal("unable to load migrations", zap.Error(err))
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up()
+		for _, mig := range done {
+			fmt.Printf("applied %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			logger.Log.Fatal("migration failed", zap.Error(err))
+		}
+		if len(done) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				os.Exit(2)
+			}
+		}
+
+		done, err := m.Down(steps)
+		for _, mig := range done {
+			fmt.Printf("rolled back %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			logger.Log.Fatal("rollback failed", zap.Error(err))
+		}
+	case "status":
+		status, err := m.Status()
+		if err != nil {
+			logger.Log.Fatal("unable to get migrations status", zap.Error(err))
+		}
+
+		for _, s := range status {
+			applied := "pending"
+			if s.Applied() {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, applied)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
+
+// checkMigrations stops the server when the schema isn't migrated to the
+// latest version
+func checkMigrations() {
+	m, err := migrations.New(db.Engine.DB().DB)
+	if err != nil {
+		logger.Log.Fatal("unable to load migrations", zap.Error(err))
+	}
+
+	pending, err := m.Pending()
+	if err != nil {
+		logger.Log.Fatal("unable to check migrations", zap.Error(err))
+	}
+
+	if len(pending) > 0 {
+		logger.Log.Fatal("database schema is not up to date, run `umg migrate up` first",
+			zap.Int("pending", len(pending)))
+	}
+}
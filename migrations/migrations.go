@@ -0,0 +1,304 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const table = "schema_migrations"
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// file names are like 0001_initial_schema.up.sql and 0001_initial_schema.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned change of the schema with its rollback
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration with the time it's applied at, zero means pending
+type Status struct {
+	*Migration
+	AppliedAt time.Time
+}
+
+func (s *Status) Applied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// Migrator applies the migrations on a database
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+// New creates a migrator for the embedded migrations
+func New(db *sql.DB) (*Migrator, error) {
+	sub, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := Load(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads the migrations of the given directory sorted by version,
+// each version must have both up and down files
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid migration version %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Status returns all migrations in order with the time they are applied at
+func (m *Migrator) Status() ([]*Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]*Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status = append(status, &Status{Migration: mig, AppliedAt: applied[mig.Version]})
+		delete(applied, mig.Version)
+	}
+
+	// applied by a newer binary, it can't be rolled back from here
+	for version := range applied {
+		return nil, fmt.Errorf("unknown migration %d is applied to the database", version)
+	}
+
+	return status, nil
+}
+
+// Pending returns the migrations that are not applied yet
+func (m *Migrator) Pending() ([]*Migration, error) {
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for _, s := range status {
+		if !s.Applied() {
+			pending = append(pending, s.Migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up applies all pending migrations in order and returns them
+func (m *Migrator) Up() ([]*Migration, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for _, mig := range pending {
+		ok, err := m.run(mig, true)
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s: %v", mig.Version, mig.Name, err)
+		}
+
+		if ok {
+			done = append(done, mig)
+		}
+	}
+
+	return done, nil
+}
+
+// Down rolls back the given number of the latest applied migrations
+func (m *Migrator) Down(steps int) ([]*Migration, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for i := len(status) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := status[i].Migration
+		if !status[i].Applied() {
+			continue
+		}
+
+		ok, err := m.run(mig, false)
+		if err != nil {
+			return done, fmt.Errorf("rollback %d_%s: %v", mig.Version, mig.Name, err)
+		}
+
+		if ok {
+			done = append(done, mig)
+		}
+	}
+
+	return done, nil
+}
+
+// run applies or rolls back a migration in a transaction, false is returned
+// when another process has done it already
+func (m *Migrator) run(mig *Migration, up bool) (bool, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// serialize concurrent migrate commands
+	if _, err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN EXCLUSIVE MODE", table)); err != nil {
+		return false, err
+	}
+
+	var count int
+	err = tx.QueryRow(fmt.Sprintf("SELECT count(*) FROM %s WHERE version = $1", table), mig.Version).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	if (count > 0) == up {
+		return false, nil
+	}
+
+	if up {
+		if _, err := tx.Exec(mig.Up); err != nil {
+			return false, err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES ($1, $2, $3)", table),
+			mig.Version, mig.Name, time.Now().UTC())
+	} else {
+		if _, err := tx.Exec(mig.Down); err != nil {
+			return false, err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = $1", table), mig.Version)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// createTable creates the migrations table if not exists, it's only called
+// before applying or rolling back migrations
+func (m *Migrator) createTable() error {
+	_, err := m.db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		version    BIGINT PRIMARY KEY,
+		name       VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`, table))
+	return err
+}
+
+// applied returns the applied versions without changing the database, none
+// of them is applied when the migrations table doesn't exist
+func (m *Migrator) applied() (map[int64]time.Time, error) {
+	var exists bool
+	if err := m.db.QueryRow("SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return make(map[int64]time.Time), nil
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf("SELECT version, applied_at FROM %s", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+
+		applied[version] = at
+	}
+
+	return applied, rows.Err()
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":        {Data: []byte("CREATE INDEX a ON b (c);")},
+		"0002_add_index.down.sql":      {Data: []byte("DROP INDEX a;")},
+		"0001_initial_schema.up.sql":   {Data: []byte("CREATE TABLE b (c INT);")},
+		"0001_initial_schema.down.sql": {Data: []byte("DROP TABLE b;")},
+		"README.md":                    {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("unable to load migrations: %v", err)
+	}
+
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(migrations))
+	}
+
+	if migrations[0].Version != 1 || migrations[0].Name != "initial_schema" || migrations[0].Down != "DROP TABLE b;" {
+		t.Errorf("unexpected first migration %+v", migrations[0])
+	}
+
+	if migrations[1].Version != 2 || migrations[1].Up != "CREATE INDEX a ON b (c);" {
+		t.Errorf("unexpected second migration %+v", migrations[1])
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_initial.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"invalid name": {
+			"initial.up.sql":   {Data: []byte("SELECT 1;")},
+			"initial.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"zero version": {
+			"0000_initial.up.sql":   {Data: []byte("SELECT 1;")},
+			"0000_initial.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"two names": {
+			"0001_initial.up.sql":   {Data: []byte("SELECT 1;")},
+			"0001_another.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, fsys := range cases {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("expected %s to fail", name)
+		}
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	m, err := New(nil)
+	if err != nil {
+		t.Fatalf("unable to load embedded migrations: %v", err)
+	}
+
+	for i, mig := range m.migrations {
+		if mig.Version != int64(i+1) {
+			t.Errorf("expected version %d, got %d", i+1, mig.Version)
+		}
+	}
+}
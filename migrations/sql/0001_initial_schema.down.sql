@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS audit_entry;
+DROP TABLE IF EXISTS history;
+DROP TABLE IF EXISTS lockout;
+DROP TABLE IF EXISTS expire;
+DROP TABLE IF EXISTS policy;
+DROP TABLE IF EXISTS property;
+DROP TABLE IF EXISTS product;
+DROP TABLE IF EXISTS action;
+DROP TABLE IF EXISTS domain;
+DROP TABLE IF EXISTS role;
+DROP TABLE IF EXISTS two_factor;
+DROP TABLE IF EXISTS password_history;
+DROP TABLE IF EXISTS "user";
@@ -0,0 +1,142 @@
+-- Schema created by xorm Sync before the migrations, existing tables and
+-- indexes are kept so that old databases can be migrated too. Columns that
+-- were added to the tables of older releases are added when they're missing.
+
+CREATE TABLE IF NOT EXISTS "user" (
+    id         BIGSERIAL PRIMARY KEY,
+    username   VARCHAR(255) NOT NULL,
+    password   VARCHAR(255) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    name       VARCHAR(255) NOT NULL,
+    company    VARCHAR(255),
+    website    VARCHAR(255),
+    address1   VARCHAR(255),
+    address2   VARCHAR(255),
+    phone1     VARCHAR(255),
+    phone2     VARCHAR(255),
+    fax1       VARCHAR(255),
+    fax2       VARCHAR(255),
+    role_ids   TEXT,
+    last_login TIMESTAMP,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_user_username" ON "user" (username);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_user_email" ON "user" (email);
+
+CREATE TABLE IF NOT EXISTS password_history (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    hash       VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "IDX_password_history_user_id" ON password_history (user_id);
+
+CREATE TABLE IF NOT EXISTS two_factor (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL,
+    secret         VARCHAR(255) NOT NULL,
+    enabled        BOOL NOT NULL,
+    last_step      BIGINT,
+    recovery_codes TEXT,
+    created_at     TIMESTAMP,
+    updated_at     TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_two_factor_user_id" ON two_factor (user_id);
+
+CREATE TABLE IF NOT EXISTS role (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(64) NOT NULL,
+    parent_ids TEXT,
+    created_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_role_name" ON role (name);
+ALTER TABLE role ADD COLUMN IF NOT EXISTS parent_ids TEXT;
+
+CREATE TABLE IF NOT EXISTS domain (
+    id        BIGSERIAL PRIMARY KEY,
+    name      VARCHAR(64) NOT NULL,
+    algorithm VARCHAR(16) NOT NULL DEFAULT 'deny-overrides'
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_domain_name" ON domain (name);
+ALTER TABLE domain ADD COLUMN IF NOT EXISTS algorithm VARCHAR(16) NOT NULL DEFAULT 'deny-overrides';
+
+CREATE TABLE IF NOT EXISTS action (
+    id          BIGSERIAL PRIMARY KEY,
+    domain_id   BIGINT NOT NULL,
+    name        VARCHAR(64) NOT NULL,
+    description VARCHAR(256)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "UQE_action_domain_action" ON action (domain_id, name);
+
+CREATE TABLE IF NOT EXISTS product (
+    id        BIGSERIAL PRIMARY KEY,
+    domain_id BIGINT,
+    name      VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS property (
+    id          BIGSERIAL PRIMARY KEY,
+    metering_id BIGINT,
+    type        VARCHAR(255),
+    name        VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS policy (
+    id         BIGSERIAL PRIMARY KEY,
+    role_id    BIGINT NOT NULL,
+    type       VARCHAR(1) NOT NULL,
+    actions    TEXT NOT NULL,
+    properties TEXT,
+    product_id BIGINT,
+    domain_id  BIGINT,
+    effect     VARCHAR(5) NOT NULL DEFAULT 'allow',
+    condition  TEXT
+);
+ALTER TABLE policy ADD COLUMN IF NOT EXISTS effect VARCHAR(5) NOT NULL DEFAULT 'allow';
+ALTER TABLE policy ADD COLUMN IF NOT EXISTS condition TEXT;
+
+CREATE TABLE IF NOT EXISTS expire (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    expire_at  TIMESTAMP,
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS lockout (
+    id           BIGSERIAL PRIMARY KEY,
+    scope        VARCHAR(8) NOT NULL,
+    subject      VARCHAR(128) NOT NULL,
+    ip           VARCHAR(64),
+    failures     BIGINT NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    unlocked_by  BIGINT,
+    created_at   TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "IDX_lockout_subject" ON lockout (subject);
+
+CREATE TABLE IF NOT EXISTS history (
+    id      BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    status  VARCHAR(255) NOT NULL,
+    "date"  TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_entry (
+    id         BIGSERIAL PRIMARY KEY,
+    actor_id   BIGINT NOT NULL,
+    actor      VARCHAR(64) NOT NULL,
+    action     VARCHAR(32) NOT NULL,
+    entity     VARCHAR(32) NOT NULL,
+    entity_id  BIGINT,
+    before     TEXT,
+    after      TEXT,
+    ip         VARCHAR(64),
+    created_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "IDX_audit_entry_actor_id" ON audit_entry (actor_id);
+CREATE INDEX IF NOT EXISTS "IDX_audit_entry_action" ON audit_entry (action);
+CREATE INDEX IF NOT EXISTS "IDX_audit_entry_entity" ON audit_entry (entity);
+CREATE INDEX IF NOT EXISTS "IDX_audit_entry_entity_id" ON audit_entry (entity_id);
+CREATE INDEX IF NOT EXISTS "IDX_audit_entry_created_at" ON audit_entry (created_at);
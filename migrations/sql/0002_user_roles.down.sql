@@ -0,0 +1,12 @@
+ALTER TABLE "user" ADD COLUMN role_ids TEXT;
+
+UPDATE "user" u
+SET role_ids = ur.role_ids
+FROM (
+    SELECT user_id, json_agg(role_id ORDER BY created_at, role_id)::text AS role_ids
+    FROM user_roles
+    GROUP BY user_id
+) ur
+WHERE ur.user_id = u.id;
+
+DROP TABLE user_roles;
@@ -0,0 +1,19 @@
+CREATE TABLE user_roles (
+    user_id    BIGINT NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    role_id    BIGINT NOT NULL REFERENCES role (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role_id)
+);
+
+-- the primary key covers lookups by user, this one finds users of a role
+CREATE INDEX "IDX_user_roles_role_id" ON user_roles (role_id);
+
+-- role_ids is a JSON array, ids of removed roles are dropped
+INSERT INTO user_roles (user_id, role_id)
+SELECT DISTINCT u.id, r.id
+FROM "user" u
+CROSS JOIN LATERAL json_array_elements_text(u.role_ids::json) AS e(role_id)
+JOIN role r ON r.id = e.role_id::bigint
+WHERE u.role_ids IS NOT NULL AND u.role_ids NOT IN ('', 'null');
+
+ALTER TABLE "user" DROP COLUMN role_ids;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "IDX_expire_user_id";
+DROP INDEX IF EXISTS "IDX_user_last_login";
+DROP INDEX IF EXISTS "IDX_user_search_trgm";
+DROP INDEX IF EXISTS "IDX_user_search_fts";
+
+-- pg_trgm is kept, other database objects may depend on it
@@ -0,0 +1,16 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- both indexes use the same document expression as the search query of
+-- rbac/users, it must be changed in both places
+CREATE INDEX "IDX_user_search_fts" ON "user" USING gin (to_tsvector('simple',
+    coalesce(username, '') || ' ' || coalesce(name, '') || ' ' || coalesce(company, '') || ' ' ||
+    coalesce(email, '') || ' ' || coalesce(phone1, '') || ' ' || coalesce(phone2, '') || ' ' ||
+    coalesce(address1, '') || ' ' || coalesce(address2, '')));
+
+CREATE INDEX "IDX_user_search_trgm" ON "user" USING gin ((
+    coalesce(username, '') || ' ' || coalesce(name, '') || ' ' || coalesce(company, '') || ' ' ||
+    coalesce(email, '') || ' ' || coalesce(phone1, '') || ' ' || coalesce(phone2, '') || ' ' ||
+    coalesce(address1, '') || ' ' || coalesce(address2, '')) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "IDX_user_last_login" ON "user" (last_login);
+CREATE INDEX IF NOT EXISTS "IDX_expire_user_id" ON expire (user_id);
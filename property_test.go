@@ -0,0 +1,109 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/rbac/domains"
+	"github.com/boof/umg/rbac/policies"
+	"github.com/boof/umg/rbac/properties"
+	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/services"
+)
+
+func TestDenyPolicyDoesNotGrantProperties(t *testing.T) {
+	// migrates the database
+	adminToken(t)
+
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+
+	domain := &domains.Domain{Name: "property" + suffix}
+	if err := domain.Save(); err != nil {
+		t.Fatalf("unable to create domain: %v", err)
+	}
+	t.Cleanup(func() { domain.RemoveByID() })
+
+	role := &roles.Role{Name: "property" + suffix}
+	if err := role.Save(); err != nil {
+		t.Fatalf("unable to create role: %v", err)
+	}
+	t.Cleanup(func() { db.Engine.ID(role.ID).Delete(&roles.Role{}) })
+
+	meteringID := time.Now().UnixNano()
+	allowed := &properties.Property{MeteringID: meteringID, Type: "METER", Name: "allowed" + suffix}
+	denied := &properties.Property{MeteringID: meteringID + 1, Type: "METER", Name: "denied" + suffix}
+	for _, property := range []*properties.Property{allowed, denied} {
+		if err := property.Save(); err != nil {
+			t.Fatalf("unable to create property: %v", err)
+		}
+		property := property
+		t.Cleanup(func() { property.RemoveByID() })
+	}
+
+	for _, policy := range []*policies.Policy{
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.DomPolicy, Actions: []string{"*"},
+			Effect: policies.Allow, Properties: []int64{allowed.ID}},
+		{RoleID: role.ID, DomainID: domain.ID, Type: policies.DomPolicy, Actions: []string{"*"},
+			Effect: policies.Deny, Properties: []int64{denied.ID}},
+	} {
+		if err := policy.Save(); err != nil {
+			t.Fatalf("unable to create policy: %v", err)
+		}
+		policy := policy
+		t.Cleanup(func() { policy.RemoveByID() })
+	}
+
+	user := &users.User{Username: "property" + suffix, Password: uuid.New().String(),
+		Email: "property" + suffix + "@example.com", Name: "property" + suffix}
+	if err := user.Save(); err != nil {
+		t.Fatalf("unable to create user: %v", err)
+	}
+	t.Cleanup(func() { user.RemoveByID() })
+
+	if err := user.AssignRole(role.ID); err != nil {
+		t.Fatalf("unable to assign role: %v", err)
+	}
+
+	ctx := context.Background()
+	if !services.HasPropertyPerm(ctx, user, allowed.MeteringID, allowed.Type) {
+		t.Error("allow policy didn't grant its property")
+	}
+
+	if services.HasPropertyPerm(ctx, user, denied.MeteringID, denied.Type) {
+		t.Error("deny policy granted its property")
+	}
+
+	props, err := services.GetProperties(user)
+	if err != nil {
+		t.Fatalf("unable to get properties: %v", err)
+	}
+
+	domProps, err := services.GetPropertiesForDomain(user, domain.Name)
+	if err != nil {
+		t.Fatalf("unable to get properties of domain: %v", err)
+	}
+
+	for _, list := range [][]properties.Property{props, domProps} {
+		has := make(map[int64]bool)
+		for _, p := range list {
+			has[p.ID] = true
+		}
+
+		if !has[allowed.ID] {
+			t.Error("allowed property isn't listed")
+		}
+
+		if has[denied.ID] {
+			t.Error("denied property is listed")
+		}
+	}
+}
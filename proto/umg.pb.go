@@ -26,12 +26,15 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // Domain Permission Request
 type DomPermReq struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Domain               string   `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
-	Action               string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// client ip and attributes of the request for policy conditions
+	Ip                   string            `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DomPermReq) Reset()         { *m = DomPermReq{} }
@@ -80,15 +83,32 @@ func (m *DomPermReq) GetAction() string {
 	return ""
 }
 
+func (m *DomPermReq) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *DomPermReq) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 // Product Permission Request
 type ProdPermReq struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Domain               string   `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
-	Product              string   `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
-	Action               string   `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Domain  string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Product string `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	Action  string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// client ip and attributes of the request for policy conditions
+	Ip                   string            `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ProdPermReq) Reset()         { *m = ProdPermReq{} }
@@ -144,6 +164,20 @@ func (m *ProdPermReq) GetAction() string {
 	return ""
 }
 
+func (m *ProdPermReq) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
+}
+
+func (m *ProdPermReq) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 // Property Permission Request
 type PropertyPermReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -663,343 +697,3393 @@ func (m *RemPropertyRes) GetMessage() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*DomPermReq)(nil), "umg.DomPermReq")
-	proto.RegisterType((*ProdPermReq)(nil), "umg.ProdPermReq")
-	proto.RegisterType((*PropertyPermReq)(nil), "umg.PropertyPermReq")
-	proto.RegisterType((*AddProdReq)(nil), "umg.AddProdReq")
-	proto.RegisterType((*RemProdReq)(nil), "umg.RemProdReq")
-	proto.RegisterType((*AddProdRes)(nil), "umg.AddProdRes")
-	proto.RegisterType((*RemProdRes)(nil), "umg.RemProdRes")
-	proto.RegisterType((*PermRes)(nil), "umg.PermRes")
-	proto.RegisterType((*AddPropertyReq)(nil), "umg.AddPropertyReq")
-	proto.RegisterType((*AddPropertyRes)(nil), "umg.AddPropertyRes")
-	proto.RegisterType((*RemPropertyReq)(nil), "umg.RemPropertyReq")
-	proto.RegisterType((*RemPropertyRes)(nil), "umg.RemPropertyRes")
+// Batch permission request, results are in the order of checks
+type BatchCheckReq struct {
+	Token  string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Checks []*PermCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	// client ip and attributes of the request for policy conditions
+	Ip                   string            `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func init() { proto.RegisterFile("proto/umg.proto", fileDescriptor_aa45786bafe6da83) }
-
-var fileDescriptor_aa45786bafe6da83 = []byte{
-	// 415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x4b, 0xeb, 0x40,
-	0x14, 0xa5, 0x49, 0x5f, 0xfb, 0xde, 0xcd, 0x7b, 0x4d, 0x99, 0x57, 0x24, 0xc4, 0x8d, 0x64, 0x25,
-	0x88, 0x15, 0x15, 0x11, 0xba, 0x10, 0x2a, 0x2e, 0x8a, 0x6e, 0x4a, 0x74, 0xe5, 0x42, 0x48, 0x3b,
-	0x97, 0x36, 0xc8, 0x64, 0x62, 0x26, 0x29, 0xf4, 0x9f, 0xbb, 0x94, 0x99, 0x49, 0x9a, 0x0f, 0x8a,
-	0x50, 0xdb, 0xdd, 0xbd, 0xa7, 0x39, 0xe7, 0x9e, 0x99, 0x3b, 0xa7, 0x60, 0xc7, 0x09, 0x4f, 0xf9,
-	0x45, 0xc6, 0x16, 0x43, 0x55, 0x11, 0x33, 0x63, 0x0b, 0xcf, 0x07, 0x78, 0xe0, 0x6c, 0x8a, 0x09,
-	0xf3, 0xf1, 0x83, 0x0c, 0xe0, 0x57, 0xca, 0xdf, 0x31, 0x72, 0x5a, 0x27, 0xad, 0xd3, 0x3f, 0xbe,
-	0x6e, 0xc8, 0x11, 0x74, 0x28, 0x67, 0x41, 0x18, 0x39, 0x86, 0x82, 0xf3, 0x4e, 0xe2, 0xc1, 0x3c,
-	0x0d, 0x79, 0xe4, 0x98, 0x1a, 0xd7, 0x9d, 0xc7, 0xc0, 0x9a, 0x26, 0x9c, 0xfe, 0x4c, 0xd4, 0x81,
-	0x6e, 0x9c, 0x70, 0x9a, 0xcd, 0xd3, 0x5c, 0xb5, 0x68, 0x2b, 0xe3, 0xda, 0xb5, 0x71, 0x4f, 0x60,
-	0x4f, 0x13, 0x1e, 0x63, 0x92, 0xae, 0xbf, 0x1f, 0xd9, 0x03, 0x23, 0xa4, 0x6a, 0x9c, 0xe9, 0x1b,
-	0x21, 0x25, 0x04, 0xda, 0xe9, 0x3a, 0xc6, 0x7c, 0x8e, 0xaa, 0xbd, 0x17, 0x80, 0x31, 0xa5, 0xd2,
-	0xfe, 0x01, 0xad, 0x4b, 0x55, 0x1f, 0xd9, 0xa1, 0x55, 0x47, 0x15, 0xaf, 0x42, 0x9e, 0x86, 0xf2,
-	0x08, 0x95, 0xe8, 0x6f, 0x5f, 0xd5, 0x92, 0xcb, 0x50, 0x88, 0x60, 0x81, 0xb9, 0x68, 0xd1, 0x4a,
-	0xee, 0xc6, 0xd1, 0xae, 0xdc, 0x63, 0xe8, 0xea, 0x8b, 0x16, 0xa4, 0x0f, 0xe6, 0x32, 0x10, 0x39,
-	0x4f, 0x96, 0xde, 0x1b, 0xf4, 0xb4, 0x29, 0xb5, 0x90, 0xbd, 0x96, 0x21, 0xb1, 0x28, 0x60, 0x98,
-	0xef, 0x5b, 0xd5, 0xde, 0x5d, 0x43, 0x7f, 0x57, 0xf3, 0x8f, 0xd0, 0xd3, 0x07, 0xdf, 0xdf, 0x9f,
-	0xf4, 0x52, 0xd3, 0xda, 0xd1, 0xcb, 0xd5, 0xa7, 0x01, 0xd6, 0x38, 0x4b, 0x97, 0xcf, 0x98, 0xac,
-	0xc2, 0x39, 0x92, 0x33, 0x80, 0x49, 0x20, 0xf2, 0x3c, 0x12, 0x7b, 0x28, 0xb3, 0x5a, 0xa6, 0xd3,
-	0xfd, 0xab, 0x80, 0xe2, 0xea, 0xcf, 0xc1, 0x9a, 0x04, 0xa2, 0x08, 0x1a, 0xe9, 0xeb, 0x1f, 0xcb,
-	0xdc, 0x35, 0x3e, 0xbf, 0x01, 0x5b, 0x7f, 0xbe, 0x09, 0x0a, 0x19, 0x14, 0x94, 0x6a, 0x76, 0x1a,
-	0xb4, 0xe1, 0xe6, 0x8d, 0xc9, 0x08, 0x6a, 0x4b, 0x65, 0x40, 0xdc, 0x06, 0x20, 0xc8, 0x25, 0xfc,
-	0xf3, 0x91, 0xf1, 0x15, 0xd6, 0x29, 0xe5, 0xeb, 0x77, 0x1b, 0x80, 0x20, 0xb7, 0x60, 0x55, 0x36,
-	0x4a, 0xfe, 0x57, 0x24, 0x8b, 0x1d, 0xb9, 0x5b, 0x40, 0x41, 0x46, 0xea, 0xfa, 0xf5, 0xac, 0x2a,
-	0xb7, 0xbe, 0x5f, 0x77, 0x0b, 0x28, 0xee, 0xdb, 0xaf, 0x46, 0x3c, 0x9b, 0x75, 0xd4, 0x3f, 0xe1,
-	0xf5, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe7, 0xce, 0x87, 0x49, 0x1c, 0x05, 0x00, 0x00,
+func (m *BatchCheckReq) Reset()         { *m = BatchCheckReq{} }
+func (m *BatchCheckReq) String() string { return proto.CompactTextString(m) }
+func (*BatchCheckReq) ProtoMessage()    {}
+func (*BatchCheckReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{12}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *BatchCheckReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCheckReq.Unmarshal(m, b)
+}
+func (m *BatchCheckReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCheckReq.Marshal(b, m, deterministic)
+}
+func (m *BatchCheckReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCheckReq.Merge(m, src)
+}
+func (m *BatchCheckReq) XXX_Size() int {
+	return xxx_messageInfo_BatchCheckReq.Size(m)
+}
+func (m *BatchCheckReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCheckReq.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_BatchCheckReq proto.InternalMessageInfo
 
-// AuthServiceClient is the client API for AuthService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AuthServiceClient interface {
-	HasDomPerm(ctx context.Context, in *DomPermReq, opts ...grpc.CallOption) (*PermRes, error)
-	HasProdPerm(ctx context.Context, in *ProdPermReq, opts ...grpc.CallOption) (*PermRes, error)
-	HasPropertyPerm(ctx context.Context, in *PropertyPermReq, opts ...grpc.CallOption) (*PermRes, error)
-	AddProduct(ctx context.Context, in *AddProdReq, opts ...grpc.CallOption) (*AddProdRes, error)
-	RemoveProduct(ctx context.Context, in *RemProdReq, opts ...grpc.CallOption) (*RemProdRes, error)
-	AddProperty(ctx context.Context, in *AddPropertyReq, opts ...grpc.CallOption) (*AddPropertyRes, error)
-	RemoveProperty(ctx context.Context, in *RemPropertyReq, opts ...grpc.CallOption) (*RemPropertyRes, error)
+func (m *BatchCheckReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
 }
 
-type authServiceClient struct {
-	cc *grpc.ClientConn
+func (m *BatchCheckReq) GetChecks() []*PermCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
 }
 
-func NewAuthServiceClient(cc *grpc.ClientConn) AuthServiceClient {
-	return &authServiceClient{cc}
+func (m *BatchCheckReq) GetIp() string {
+	if m != nil {
+		return m.Ip
+	}
+	return ""
 }
 
-func (c *authServiceClient) HasDomPerm(ctx context.Context, in *DomPermReq, opts ...grpc.CallOption) (*PermRes, error) {
-	out := new(PermRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/HasDomPerm", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchCheckReq) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *authServiceClient) HasProdPerm(ctx context.Context, in *ProdPermReq, opts ...grpc.CallOption) (*PermRes, error) {
-	out := new(PermRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/HasProdPerm", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// single check of a batch, domain checks don't have product and
+// property checks only have property_id and property_type
+type PermCheck struct {
+	Domain               string   `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Product              string   `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	Action               string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	PropertyId           int64    `protobuf:"varint,4,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	PropertyType         string   `protobuf:"bytes,5,opt,name=property_type,json=propertyType,proto3" json:"property_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *authServiceClient) HasPropertyPerm(ctx context.Context, in *PropertyPermReq, opts ...grpc.CallOption) (*PermRes, error) {
-	out := new(PermRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/HasPropertyPerm", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PermCheck) Reset()         { *m = PermCheck{} }
+func (m *PermCheck) String() string { return proto.CompactTextString(m) }
+func (*PermCheck) ProtoMessage()    {}
+func (*PermCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{13}
+}
+
+func (m *PermCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermCheck.Unmarshal(m, b)
+}
+func (m *PermCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermCheck.Marshal(b, m, deterministic)
+}
+func (m *PermCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermCheck.Merge(m, src)
+}
+func (m *PermCheck) XXX_Size() int {
+	return xxx_messageInfo_PermCheck.Size(m)
+}
+func (m *PermCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PermCheck proto.InternalMessageInfo
+
+func (m *PermCheck) GetDomain() string {
+	if m != nil {
+		return m.Domain
 	}
-	return out, nil
+	return ""
 }
 
-func (c *authServiceClient) AddProduct(ctx context.Context, in *AddProdReq, opts ...grpc.CallOption) (*AddProdRes, error) {
-	out := new(AddProdRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/AddProduct", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PermCheck) GetProduct() string {
+	if m != nil {
+		return m.Product
 	}
-	return out, nil
+	return ""
 }
 
-func (c *authServiceClient) RemoveProduct(ctx context.Context, in *RemProdReq, opts ...grpc.CallOption) (*RemProdRes, error) {
-	out := new(RemProdRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/RemoveProduct", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PermCheck) GetAction() string {
+	if m != nil {
+		return m.Action
 	}
-	return out, nil
+	return ""
 }
 
-func (c *authServiceClient) AddProperty(ctx context.Context, in *AddPropertyReq, opts ...grpc.CallOption) (*AddPropertyRes, error) {
-	out := new(AddPropertyRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/AddProperty", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PermCheck) GetPropertyId() int64 {
+	if m != nil {
+		return m.PropertyId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *authServiceClient) RemoveProperty(ctx context.Context, in *RemPropertyReq, opts ...grpc.CallOption) (*RemPropertyRes, error) {
-	out := new(RemPropertyRes)
-	err := c.cc.Invoke(ctx, "/umg.AuthService/RemoveProperty", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PermCheck) GetPropertyType() string {
+	if m != nil {
+		return m.PropertyType
 	}
-	return out, nil
+	return ""
 }
 
-// AuthServiceServer is the server API for AuthService service.
-type AuthServiceServer interface {
-	HasDomPerm(context.Context, *DomPermReq) (*PermRes, error)
-	HasProdPerm(context.Context, *ProdPermReq) (*PermRes, error)
-	HasPropertyPerm(context.Context, *PropertyPermReq) (*PermRes, error)
-	AddProduct(context.Context, *AddProdReq) (*AddProdRes, error)
-	RemoveProduct(context.Context, *RemProdReq) (*RemProdRes, error)
-	AddProperty(context.Context, *AddPropertyReq) (*AddPropertyRes, error)
-	RemoveProperty(context.Context, *RemPropertyReq) (*RemPropertyRes, error)
+// Batch permission response
+type BatchCheckRes struct {
+	Results              []bool   `protobuf:"varint,1,rep,packed,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedAuthServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedAuthServiceServer struct {
+func (m *BatchCheckRes) Reset()         { *m = BatchCheckRes{} }
+func (m *BatchCheckRes) String() string { return proto.CompactTextString(m) }
+func (*BatchCheckRes) ProtoMessage()    {}
+func (*BatchCheckRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{14}
 }
 
-func (*UnimplementedAuthServiceServer) HasDomPerm(ctx context.Context, req *DomPermReq) (*PermRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HasDomPerm not implemented")
+func (m *BatchCheckRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCheckRes.Unmarshal(m, b)
 }
-func (*UnimplementedAuthServiceServer) HasProdPerm(ctx context.Context, req *ProdPermReq) (*PermRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HasProdPerm not implemented")
+func (m *BatchCheckRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCheckRes.Marshal(b, m, deterministic)
 }
-func (*UnimplementedAuthServiceServer) HasPropertyPerm(ctx context.Context, req *PropertyPermReq) (*PermRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method HasPropertyPerm not implemented")
+func (m *BatchCheckRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCheckRes.Merge(m, src)
 }
-func (*UnimplementedAuthServiceServer) AddProduct(ctx context.Context, req *AddProdReq) (*AddProdRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+func (m *BatchCheckRes) XXX_Size() int {
+	return xxx_messageInfo_BatchCheckRes.Size(m)
 }
-func (*UnimplementedAuthServiceServer) RemoveProduct(ctx context.Context, req *RemProdReq) (*RemProdRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveProduct not implemented")
+func (m *BatchCheckRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCheckRes.DiscardUnknown(m)
 }
-func (*UnimplementedAuthServiceServer) AddProperty(ctx context.Context, req *AddPropertyReq) (*AddPropertyRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddProperty not implemented")
+
+var xxx_messageInfo_BatchCheckRes proto.InternalMessageInfo
+
+func (m *BatchCheckRes) GetResults() []bool {
+	if m != nil {
+		return m.Results
+	}
+	return nil
 }
-func (*UnimplementedAuthServiceServer) RemoveProperty(ctx context.Context, req *RemPropertyReq) (*RemPropertyRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveProperty not implemented")
+
+// List allowed actions request
+type ListAllowedReq struct {
+	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// client ip and attributes of the request for policy conditions
+	Ip                   string            `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Attributes           map[string]string `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func RegisterAuthServiceServer(s *grpc.Server, srv AuthServiceServer) {
-	s.RegisterService(&_AuthService_serviceDesc, srv)
+func (m *ListAllowedReq) Reset()         { *m = ListAllowedReq{} }
+func (m *ListAllowedReq) String() string { return proto.CompactTextString(m) }
+func (*ListAllowedReq) ProtoMessage()    {}
+func (*ListAllowedReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{15}
 }
 
-func _AuthService_HasDomPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DomPermReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).HasDomPerm(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/umg.AuthService/HasDomPerm",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).HasDomPerm(ctx, req.(*DomPermReq))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *ListAllowedReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAllowedReq.Unmarshal(m, b)
+}
+func (m *ListAllowedReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAllowedReq.Marshal(b, m, deterministic)
+}
+func (m *ListAllowedReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAllowedReq.Merge(m, src)
+}
+func (m *ListAllowedReq) XXX_Size() int {
+	return xxx_messageInfo_ListAllowedReq.Size(m)
+}
+func (m *ListAllowedReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAllowedReq.DiscardUnknown(m)
 }
 
-func _AuthService_HasProdPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProdPermReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).HasProdPerm(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/umg.AuthService/HasProdPerm",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).HasProdPerm(ctx, req.(*ProdPermReq))
+var xxx_messageInfo_ListAllowedReq proto.InternalMessageInfo
+
+func (m *ListAllowedReq) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AuthService_HasPropertyPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PropertyPermReq)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).HasPropertyPerm(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/umg.AuthService/HasPropertyPerm",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).HasPropertyPerm(ctx, req.(*PropertyPermReq))
+func (m *ListAllowedReq) GetDomain() string {
+	if m != nil {
+		return m.Domain
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _AuthService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddProdReq)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ListAllowedReq) GetIp() string {
+	if m != nil {
+		return m.Ip
 	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).AddProduct(ctx, in)
+	return ""
+}
+
+func (m *ListAllowedReq) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
+	return nil
+}
+
+// product and the allowed actions on it
+type AllowedProduct struct {
+	Product              string   `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Actions              []string `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllowedProduct) Reset()         { *m = AllowedProduct{} }
+func (m *AllowedProduct) String() string { return proto.CompactTextString(m) }
+func (*AllowedProduct) ProtoMessage()    {}
+func (*AllowedProduct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{16}
+}
+
+func (m *AllowedProduct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllowedProduct.Unmarshal(m, b)
+}
+func (m *AllowedProduct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllowedProduct.Marshal(b, m, deterministic)
+}
+func (m *AllowedProduct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllowedProduct.Merge(m, src)
+}
+func (m *AllowedProduct) XXX_Size() int {
+	return xxx_messageInfo_AllowedProduct.Size(m)
+}
+func (m *AllowedProduct) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllowedProduct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllowedProduct proto.InternalMessageInfo
+
+func (m *AllowedProduct) GetProduct() string {
+	if m != nil {
+		return m.Product
+	}
+	return ""
+}
+
+func (m *AllowedProduct) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+// List allowed actions response
+type ListAllowedRes struct {
+	DomainActions        []string          `protobuf:"bytes,1,rep,name=domain_actions,json=domainActions,proto3" json:"domain_actions,omitempty"`
+	Products             []*AllowedProduct `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListAllowedRes) Reset()         { *m = ListAllowedRes{} }
+func (m *ListAllowedRes) String() string { return proto.CompactTextString(m) }
+func (*ListAllowedRes) ProtoMessage()    {}
+func (*ListAllowedRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{17}
+}
+
+func (m *ListAllowedRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAllowedRes.Unmarshal(m, b)
+}
+func (m *ListAllowedRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAllowedRes.Marshal(b, m, deterministic)
+}
+func (m *ListAllowedRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAllowedRes.Merge(m, src)
+}
+func (m *ListAllowedRes) XXX_Size() int {
+	return xxx_messageInfo_ListAllowedRes.Size(m)
+}
+func (m *ListAllowedRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAllowedRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAllowedRes proto.InternalMessageInfo
+
+func (m *ListAllowedRes) GetDomainActions() []string {
+	if m != nil {
+		return m.DomainActions
+	}
+	return nil
+}
+
+func (m *ListAllowedRes) GetProducts() []*AllowedProduct {
+	if m != nil {
+		return m.Products
+	}
+	return nil
+}
+
+// User of the management service, password is only used for adding users
+type User struct {
+	Id       int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username string  `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password string  `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Email    string  `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Name     string  `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Company  string  `protobuf:"bytes,6,opt,name=company,proto3" json:"company,omitempty"`
+	Website  string  `protobuf:"bytes,7,opt,name=website,proto3" json:"website,omitempty"`
+	Address1 string  `protobuf:"bytes,8,opt,name=address1,proto3" json:"address1,omitempty"`
+	Address2 string  `protobuf:"bytes,9,opt,name=address2,proto3" json:"address2,omitempty"`
+	Phone1   string  `protobuf:"bytes,10,opt,name=phone1,proto3" json:"phone1,omitempty"`
+	Phone2   string  `protobuf:"bytes,11,opt,name=phone2,proto3" json:"phone2,omitempty"`
+	Fax1     string  `protobuf:"bytes,12,opt,name=fax1,proto3" json:"fax1,omitempty"`
+	Fax2     string  `protobuf:"bytes,13,opt,name=fax2,proto3" json:"fax2,omitempty"`
+	RoleIds  []int64 `protobuf:"varint,14,rep,packed,name=role_ids,json=roleIds,proto3" json:"role_ids,omitempty"`
+	// last login time in 2006-01-02T15:04:05 layout
+	LastLogin            string   `protobuf:"bytes,15,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{18}
+}
+
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_User.Unmarshal(m, b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+}
+func (m *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(m, src)
+}
+func (m *User) XXX_Size() int {
+	return xxx_messageInfo_User.Size(m)
+}
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User proto.InternalMessageInfo
+
+func (m *User) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *User) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *User) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *User) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *User) GetCompany() string {
+	if m != nil {
+		return m.Company
+	}
+	return ""
+}
+
+func (m *User) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *User) GetAddress1() string {
+	if m != nil {
+		return m.Address1
+	}
+	return ""
+}
+
+func (m *User) GetAddress2() string {
+	if m != nil {
+		return m.Address2
+	}
+	return ""
+}
+
+func (m *User) GetPhone1() string {
+	if m != nil {
+		return m.Phone1
+	}
+	return ""
+}
+
+func (m *User) GetPhone2() string {
+	if m != nil {
+		return m.Phone2
+	}
+	return ""
+}
+
+func (m *User) GetFax1() string {
+	if m != nil {
+		return m.Fax1
+	}
+	return ""
+}
+
+func (m *User) GetFax2() string {
+	if m != nil {
+		return m.Fax2
+	}
+	return ""
+}
+
+func (m *User) GetRoleIds() []int64 {
+	if m != nil {
+		return m.RoleIds
+	}
+	return nil
+}
+
+func (m *User) GetLastLogin() string {
+	if m != nil {
+		return m.LastLogin
+	}
+	return ""
+}
+
+type Role struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentIds            []int64  `protobuf:"varint,3,rep,packed,name=parent_ids,json=parentIds,proto3" json:"parent_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Role) Reset()         { *m = Role{} }
+func (m *Role) String() string { return proto.CompactTextString(m) }
+func (*Role) ProtoMessage()    {}
+func (*Role) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{19}
+}
+
+func (m *Role) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Role.Unmarshal(m, b)
+}
+func (m *Role) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Role.Marshal(b, m, deterministic)
+}
+func (m *Role) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Role.Merge(m, src)
+}
+func (m *Role) XXX_Size() int {
+	return xxx_messageInfo_Role.Size(m)
+}
+func (m *Role) XXX_DiscardUnknown() {
+	xxx_messageInfo_Role.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Role proto.InternalMessageInfo
+
+func (m *Role) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Role) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Role) GetParentIds() []int64 {
+	if m != nil {
+		return m.ParentIds
+	}
+	return nil
+}
+
+// policy type is D for a domain, P for a product and A for all products of a domain
+type Policy struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoleId               int64    `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Type                 string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Actions              []string `protobuf:"bytes,4,rep,name=actions,proto3" json:"actions,omitempty"`
+	Properties           []int64  `protobuf:"varint,5,rep,packed,name=properties,proto3" json:"properties,omitempty"`
+	ProductId            int64    `protobuf:"varint,6,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	DomainId             int64    `protobuf:"varint,7,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	Effect               string   `protobuf:"bytes,8,opt,name=effect,proto3" json:"effect,omitempty"`
+	Condition            string   `protobuf:"bytes,9,opt,name=condition,proto3" json:"condition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Policy) Reset()         { *m = Policy{} }
+func (m *Policy) String() string { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()    {}
+func (*Policy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{20}
+}
+
+func (m *Policy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Policy.Unmarshal(m, b)
+}
+func (m *Policy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Policy.Marshal(b, m, deterministic)
+}
+func (m *Policy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Policy.Merge(m, src)
+}
+func (m *Policy) XXX_Size() int {
+	return xxx_messageInfo_Policy.Size(m)
+}
+func (m *Policy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Policy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Policy proto.InternalMessageInfo
+
+func (m *Policy) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Policy) GetRoleId() int64 {
+	if m != nil {
+		return m.RoleId
+	}
+	return 0
+}
+
+func (m *Policy) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Policy) GetActions() []string {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+func (m *Policy) GetProperties() []int64 {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Policy) GetProductId() int64 {
+	if m != nil {
+		return m.ProductId
+	}
+	return 0
+}
+
+func (m *Policy) GetDomainId() int64 {
+	if m != nil {
+		return m.DomainId
+	}
+	return 0
+}
+
+func (m *Policy) GetEffect() string {
+	if m != nil {
+		return m.Effect
+	}
+	return ""
+}
+
+func (m *Policy) GetCondition() string {
+	if m != nil {
+		return m.Condition
+	}
+	return ""
+}
+
+type Domain struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Algorithm            string   `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Domain) Reset()         { *m = Domain{} }
+func (m *Domain) String() string { return proto.CompactTextString(m) }
+func (*Domain) ProtoMessage()    {}
+func (*Domain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{21}
+}
+
+func (m *Domain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Domain.Unmarshal(m, b)
+}
+func (m *Domain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Domain.Marshal(b, m, deterministic)
+}
+func (m *Domain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Domain.Merge(m, src)
+}
+func (m *Domain) XXX_Size() int {
+	return xxx_messageInfo_Domain.Size(m)
+}
+func (m *Domain) XXX_DiscardUnknown() {
+	xxx_messageInfo_Domain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Domain proto.InternalMessageInfo
+
+func (m *Domain) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Domain) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Domain) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+// action in the catalogue of a domain
+type DomainAction struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DomainId             int64    `protobuf:"varint,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DomainAction) Reset()         { *m = DomainAction{} }
+func (m *DomainAction) String() string { return proto.CompactTextString(m) }
+func (*DomainAction) ProtoMessage()    {}
+func (*DomainAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{22}
+}
+
+func (m *DomainAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DomainAction.Unmarshal(m, b)
+}
+func (m *DomainAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DomainAction.Marshal(b, m, deterministic)
+}
+func (m *DomainAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DomainAction.Merge(m, src)
+}
+func (m *DomainAction) XXX_Size() int {
+	return xxx_messageInfo_DomainAction.Size(m)
+}
+func (m *DomainAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_DomainAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DomainAction proto.InternalMessageInfo
+
+func (m *DomainAction) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DomainAction) GetDomainId() int64 {
+	if m != nil {
+		return m.DomainId
+	}
+	return 0
+}
+
+func (m *DomainAction) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DomainAction) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// access expire of a user, expire_at is in 2006-01-02T15:04:05 layout
+type AccessExpire struct {
+	UserId               int64    `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpireAt             string   `protobuf:"bytes,2,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessExpire) Reset()         { *m = AccessExpire{} }
+func (m *AccessExpire) String() string { return proto.CompactTextString(m) }
+func (*AccessExpire) ProtoMessage()    {}
+func (*AccessExpire) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{23}
+}
+
+func (m *AccessExpire) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessExpire.Unmarshal(m, b)
+}
+func (m *AccessExpire) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessExpire.Marshal(b, m, deterministic)
+}
+func (m *AccessExpire) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessExpire.Merge(m, src)
+}
+func (m *AccessExpire) XXX_Size() int {
+	return xxx_messageInfo_AccessExpire.Size(m)
+}
+func (m *AccessExpire) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessExpire.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessExpire proto.InternalMessageInfo
+
+func (m *AccessExpire) GetUserId() int64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *AccessExpire) GetExpireAt() string {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return ""
+}
+
+// request of the methods that only need id of an entity
+type EntityReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntityReq) Reset()         { *m = EntityReq{} }
+func (m *EntityReq) String() string { return proto.CompactTextString(m) }
+func (*EntityReq) ProtoMessage()    {}
+func (*EntityReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{24}
+}
+
+func (m *EntityReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntityReq.Unmarshal(m, b)
+}
+func (m *EntityReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntityReq.Marshal(b, m, deterministic)
+}
+func (m *EntityReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntityReq.Merge(m, src)
+}
+func (m *EntityReq) XXX_Size() int {
+	return xxx_messageInfo_EntityReq.Size(m)
+}
+func (m *EntityReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntityReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntityReq proto.InternalMessageInfo
+
+func (m *EntityReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *EntityReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// request of the methods that only need the caller token
+type TokenReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenReq) Reset()         { *m = TokenReq{} }
+func (m *TokenReq) String() string { return proto.CompactTextString(m) }
+func (*TokenReq) ProtoMessage()    {}
+func (*TokenReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{25}
+}
+
+func (m *TokenReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenReq.Unmarshal(m, b)
+}
+func (m *TokenReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenReq.Marshal(b, m, deterministic)
+}
+func (m *TokenReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenReq.Merge(m, src)
+}
+func (m *TokenReq) XXX_Size() int {
+	return xxx_messageInfo_TokenReq.Size(m)
+}
+func (m *TokenReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenReq proto.InternalMessageInfo
+
+func (m *TokenReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// response of the management mutations, id is the id of created entities
+type DoneRes struct {
+	Done                 bool     `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DoneRes) Reset()         { *m = DoneRes{} }
+func (m *DoneRes) String() string { return proto.CompactTextString(m) }
+func (*DoneRes) ProtoMessage()    {}
+func (*DoneRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{26}
+}
+
+func (m *DoneRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoneRes.Unmarshal(m, b)
+}
+func (m *DoneRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoneRes.Marshal(b, m, deterministic)
+}
+func (m *DoneRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoneRes.Merge(m, src)
+}
+func (m *DoneRes) XXX_Size() int {
+	return xxx_messageInfo_DoneRes.Size(m)
+}
+func (m *DoneRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoneRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DoneRes proto.InternalMessageInfo
+
+func (m *DoneRes) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *DoneRes) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// paginated list request, order is asc or desc and count is at most 20
+type ListReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Page                 int64    `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Order                string   `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Sort                 string   `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReq) Reset()         { *m = ListReq{} }
+func (m *ListReq) String() string { return proto.CompactTextString(m) }
+func (*ListReq) ProtoMessage()    {}
+func (*ListReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{27}
+}
+
+func (m *ListReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListReq.Unmarshal(m, b)
+}
+func (m *ListReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListReq.Marshal(b, m, deterministic)
+}
+func (m *ListReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReq.Merge(m, src)
+}
+func (m *ListReq) XXX_Size() int {
+	return xxx_messageInfo_ListReq.Size(m)
+}
+func (m *ListReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReq proto.InternalMessageInfo
+
+func (m *ListReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ListReq) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ListReq) GetPage() int64 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *ListReq) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *ListReq) GetSort() string {
+	if m != nil {
+		return m.Sort
+	}
+	return ""
+}
+
+type ListUsersRes struct {
+	Users                []*User  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	PageCount            int64    `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUsersRes) Reset()         { *m = ListUsersRes{} }
+func (m *ListUsersRes) String() string { return proto.CompactTextString(m) }
+func (*ListUsersRes) ProtoMessage()    {}
+func (*ListUsersRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{28}
+}
+
+func (m *ListUsersRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUsersRes.Unmarshal(m, b)
+}
+func (m *ListUsersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUsersRes.Marshal(b, m, deterministic)
+}
+func (m *ListUsersRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUsersRes.Merge(m, src)
+}
+func (m *ListUsersRes) XXX_Size() int {
+	return xxx_messageInfo_ListUsersRes.Size(m)
+}
+func (m *ListUsersRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUsersRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUsersRes proto.InternalMessageInfo
+
+func (m *ListUsersRes) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func (m *ListUsersRes) GetPageCount() int64 {
+	if m != nil {
+		return m.PageCount
+	}
+	return 0
+}
+
+// Add user request, expire_at is optional and in 2006-01-02T15:04:05 layout
+type AddUserReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User                 *User    `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	SendEmail            bool     `protobuf:"varint,3,opt,name=send_email,json=sendEmail,proto3" json:"send_email,omitempty"`
+	ExpireAt             string   `protobuf:"bytes,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddUserReq) Reset()         { *m = AddUserReq{} }
+func (m *AddUserReq) String() string { return proto.CompactTextString(m) }
+func (*AddUserReq) ProtoMessage()    {}
+func (*AddUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{29}
+}
+
+func (m *AddUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddUserReq.Unmarshal(m, b)
+}
+func (m *AddUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddUserReq.Marshal(b, m, deterministic)
+}
+func (m *AddUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddUserReq.Merge(m, src)
+}
+func (m *AddUserReq) XXX_Size() int {
+	return xxx_messageInfo_AddUserReq.Size(m)
+}
+func (m *AddUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddUserReq proto.InternalMessageInfo
+
+func (m *AddUserReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AddUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *AddUserReq) GetSendEmail() bool {
+	if m != nil {
+		return m.SendEmail
+	}
+	return false
+}
+
+func (m *AddUserReq) GetExpireAt() string {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return ""
+}
+
+type UpdateUserReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User                 *User    `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateUserReq) Reset()         { *m = UpdateUserReq{} }
+func (m *UpdateUserReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserReq) ProtoMessage()    {}
+func (*UpdateUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{30}
+}
+
+func (m *UpdateUserReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserReq.Unmarshal(m, b)
+}
+func (m *UpdateUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserReq.Merge(m, src)
+}
+func (m *UpdateUserReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserReq.Size(m)
+}
+func (m *UpdateUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserReq proto.InternalMessageInfo
+
+func (m *UpdateUserReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *UpdateUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type ListRolesRes struct {
+	Roles                []*Role  `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	PageCount            int64    `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRolesRes) Reset()         { *m = ListRolesRes{} }
+func (m *ListRolesRes) String() string { return proto.CompactTextString(m) }
+func (*ListRolesRes) ProtoMessage()    {}
+func (*ListRolesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{31}
+}
+
+func (m *ListRolesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRolesRes.Unmarshal(m, b)
+}
+func (m *ListRolesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRolesRes.Marshal(b, m, deterministic)
+}
+func (m *ListRolesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRolesRes.Merge(m, src)
+}
+func (m *ListRolesRes) XXX_Size() int {
+	return xxx_messageInfo_ListRolesRes.Size(m)
+}
+func (m *ListRolesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRolesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRolesRes proto.InternalMessageInfo
+
+func (m *ListRolesRes) GetRoles() []*Role {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *ListRolesRes) GetPageCount() int64 {
+	if m != nil {
+		return m.PageCount
+	}
+	return 0
+}
+
+// Add role request, the role isn't added if any of the policies is invalid
+type AddRoleReq struct {
+	Token                string    `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Role                 *Role     `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Policies             []*Policy `protobuf:"bytes,3,rep,name=policies,proto3" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *AddRoleReq) Reset()         { *m = AddRoleReq{} }
+func (m *AddRoleReq) String() string { return proto.CompactTextString(m) }
+func (*AddRoleReq) ProtoMessage()    {}
+func (*AddRoleReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{32}
+}
+
+func (m *AddRoleReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddRoleReq.Unmarshal(m, b)
+}
+func (m *AddRoleReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddRoleReq.Marshal(b, m, deterministic)
+}
+func (m *AddRoleReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRoleReq.Merge(m, src)
+}
+func (m *AddRoleReq) XXX_Size() int {
+	return xxx_messageInfo_AddRoleReq.Size(m)
+}
+func (m *AddRoleReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRoleReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddRoleReq proto.InternalMessageInfo
+
+func (m *AddRoleReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AddRoleReq) GetRole() *Role {
+	if m != nil {
+		return m.Role
+	}
+	return nil
+}
+
+func (m *AddRoleReq) GetPolicies() []*Policy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+type UpdateRoleReq struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Role  *Role  `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// parents of the role are kept when parent_ids is empty, unless it's set
+	ClearParents         bool     `protobuf:"varint,3,opt,name=clear_parents,json=clearParents,proto3" json:"clear_parents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRoleReq) Reset()         { *m = UpdateRoleReq{} }
+func (m *UpdateRoleReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateRoleReq) ProtoMessage()    {}
+func (*UpdateRoleReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{33}
+}
+
+func (m *UpdateRoleReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRoleReq.Unmarshal(m, b)
+}
+func (m *UpdateRoleReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRoleReq.Marshal(b, m, deterministic)
+}
+func (m *UpdateRoleReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRoleReq.Merge(m, src)
+}
+func (m *UpdateRoleReq) XXX_Size() int {
+	return xxx_messageInfo_UpdateRoleReq.Size(m)
+}
+func (m *UpdateRoleReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRoleReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRoleReq proto.InternalMessageInfo
+
+func (m *UpdateRoleReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *UpdateRoleReq) GetRole() *Role {
+	if m != nil {
+		return m.Role
+	}
+	return nil
+}
+
+func (m *UpdateRoleReq) GetClearParents() bool {
+	if m != nil {
+		return m.ClearParents
+	}
+	return false
+}
+
+type RoleAssignmentReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId               int64    `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoleId               int64    `protobuf:"varint,3,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleAssignmentReq) Reset()         { *m = RoleAssignmentReq{} }
+func (m *RoleAssignmentReq) String() string { return proto.CompactTextString(m) }
+func (*RoleAssignmentReq) ProtoMessage()    {}
+func (*RoleAssignmentReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{34}
+}
+
+func (m *RoleAssignmentReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleAssignmentReq.Unmarshal(m, b)
+}
+func (m *RoleAssignmentReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleAssignmentReq.Marshal(b, m, deterministic)
+}
+func (m *RoleAssignmentReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleAssignmentReq.Merge(m, src)
+}
+func (m *RoleAssignmentReq) XXX_Size() int {
+	return xxx_messageInfo_RoleAssignmentReq.Size(m)
+}
+func (m *RoleAssignmentReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleAssignmentReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleAssignmentReq proto.InternalMessageInfo
+
+func (m *RoleAssignmentReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RoleAssignmentReq) GetUserId() int64 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *RoleAssignmentReq) GetRoleId() int64 {
+	if m != nil {
+		return m.RoleId
+	}
+	return 0
+}
+
+// List users request of the users that the role is assigned to, sorted by id
+type ListRoleUsersReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RoleId               int64    `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Page                 int64    `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoleUsersReq) Reset()         { *m = ListRoleUsersReq{} }
+func (m *ListRoleUsersReq) String() string { return proto.CompactTextString(m) }
+func (*ListRoleUsersReq) ProtoMessage()    {}
+func (*ListRoleUsersReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{35}
+}
+
+func (m *ListRoleUsersReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoleUsersReq.Unmarshal(m, b)
+}
+func (m *ListRoleUsersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoleUsersReq.Marshal(b, m, deterministic)
+}
+func (m *ListRoleUsersReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoleUsersReq.Merge(m, src)
+}
+func (m *ListRoleUsersReq) XXX_Size() int {
+	return xxx_messageInfo_ListRoleUsersReq.Size(m)
+}
+func (m *ListRoleUsersReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoleUsersReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoleUsersReq proto.InternalMessageInfo
+
+func (m *ListRoleUsersReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ListRoleUsersReq) GetRoleId() int64 {
+	if m != nil {
+		return m.RoleId
+	}
+	return 0
+}
+
+func (m *ListRoleUsersReq) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ListRoleUsersReq) GetPage() int64 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+// List policies request, effective includes policies of the parent roles
+type ListPoliciesReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RoleId               int64    `protobuf:"varint,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	Effective            bool     `protobuf:"varint,3,opt,name=effective,proto3" json:"effective,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPoliciesReq) Reset()         { *m = ListPoliciesReq{} }
+func (m *ListPoliciesReq) String() string { return proto.CompactTextString(m) }
+func (*ListPoliciesReq) ProtoMessage()    {}
+func (*ListPoliciesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{36}
+}
+
+func (m *ListPoliciesReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPoliciesReq.Unmarshal(m, b)
+}
+func (m *ListPoliciesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPoliciesReq.Marshal(b, m, deterministic)
+}
+func (m *ListPoliciesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPoliciesReq.Merge(m, src)
+}
+func (m *ListPoliciesReq) XXX_Size() int {
+	return xxx_messageInfo_ListPoliciesReq.Size(m)
+}
+func (m *ListPoliciesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPoliciesReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPoliciesReq proto.InternalMessageInfo
+
+func (m *ListPoliciesReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ListPoliciesReq) GetRoleId() int64 {
+	if m != nil {
+		return m.RoleId
+	}
+	return 0
+}
+
+func (m *ListPoliciesReq) GetEffective() bool {
+	if m != nil {
+		return m.Effective
+	}
+	return false
+}
+
+type ListPoliciesRes struct {
+	Policies             []*Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListPoliciesRes) Reset()         { *m = ListPoliciesRes{} }
+func (m *ListPoliciesRes) String() string { return proto.CompactTextString(m) }
+func (*ListPoliciesRes) ProtoMessage()    {}
+func (*ListPoliciesRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{37}
+}
+
+func (m *ListPoliciesRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPoliciesRes.Unmarshal(m, b)
+}
+func (m *ListPoliciesRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPoliciesRes.Marshal(b, m, deterministic)
+}
+func (m *ListPoliciesRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPoliciesRes.Merge(m, src)
+}
+func (m *ListPoliciesRes) XXX_Size() int {
+	return xxx_messageInfo_ListPoliciesRes.Size(m)
+}
+func (m *ListPoliciesRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPoliciesRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPoliciesRes proto.InternalMessageInfo
+
+func (m *ListPoliciesRes) GetPolicies() []*Policy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+type AddPolicyReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Policy               *Policy  `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPolicyReq) Reset()         { *m = AddPolicyReq{} }
+func (m *AddPolicyReq) String() string { return proto.CompactTextString(m) }
+func (*AddPolicyReq) ProtoMessage()    {}
+func (*AddPolicyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{38}
+}
+
+func (m *AddPolicyReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPolicyReq.Unmarshal(m, b)
+}
+func (m *AddPolicyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPolicyReq.Marshal(b, m, deterministic)
+}
+func (m *AddPolicyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPolicyReq.Merge(m, src)
+}
+func (m *AddPolicyReq) XXX_Size() int {
+	return xxx_messageInfo_AddPolicyReq.Size(m)
+}
+func (m *AddPolicyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPolicyReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPolicyReq proto.InternalMessageInfo
+
+func (m *AddPolicyReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AddPolicyReq) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type ListDomainsRes struct {
+	Domains              []*Domain `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListDomainsRes) Reset()         { *m = ListDomainsRes{} }
+func (m *ListDomainsRes) String() string { return proto.CompactTextString(m) }
+func (*ListDomainsRes) ProtoMessage()    {}
+func (*ListDomainsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{39}
+}
+
+func (m *ListDomainsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDomainsRes.Unmarshal(m, b)
+}
+func (m *ListDomainsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDomainsRes.Marshal(b, m, deterministic)
+}
+func (m *ListDomainsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDomainsRes.Merge(m, src)
+}
+func (m *ListDomainsRes) XXX_Size() int {
+	return xxx_messageInfo_ListDomainsRes.Size(m)
+}
+func (m *ListDomainsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDomainsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDomainsRes proto.InternalMessageInfo
+
+func (m *ListDomainsRes) GetDomains() []*Domain {
+	if m != nil {
+		return m.Domains
+	}
+	return nil
+}
+
+type AddDomainReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Domain               *Domain  `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddDomainReq) Reset()         { *m = AddDomainReq{} }
+func (m *AddDomainReq) String() string { return proto.CompactTextString(m) }
+func (*AddDomainReq) ProtoMessage()    {}
+func (*AddDomainReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{40}
+}
+
+func (m *AddDomainReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddDomainReq.Unmarshal(m, b)
+}
+func (m *AddDomainReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddDomainReq.Marshal(b, m, deterministic)
+}
+func (m *AddDomainReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddDomainReq.Merge(m, src)
+}
+func (m *AddDomainReq) XXX_Size() int {
+	return xxx_messageInfo_AddDomainReq.Size(m)
+}
+func (m *AddDomainReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddDomainReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddDomainReq proto.InternalMessageInfo
+
+func (m *AddDomainReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AddDomainReq) GetDomain() *Domain {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+type SetDomainAlgorithmReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Algorithm            string   `protobuf:"bytes,3,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDomainAlgorithmReq) Reset()         { *m = SetDomainAlgorithmReq{} }
+func (m *SetDomainAlgorithmReq) String() string { return proto.CompactTextString(m) }
+func (*SetDomainAlgorithmReq) ProtoMessage()    {}
+func (*SetDomainAlgorithmReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{41}
+}
+
+func (m *SetDomainAlgorithmReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDomainAlgorithmReq.Unmarshal(m, b)
+}
+func (m *SetDomainAlgorithmReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDomainAlgorithmReq.Marshal(b, m, deterministic)
+}
+func (m *SetDomainAlgorithmReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDomainAlgorithmReq.Merge(m, src)
+}
+func (m *SetDomainAlgorithmReq) XXX_Size() int {
+	return xxx_messageInfo_SetDomainAlgorithmReq.Size(m)
+}
+func (m *SetDomainAlgorithmReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDomainAlgorithmReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDomainAlgorithmReq proto.InternalMessageInfo
+
+func (m *SetDomainAlgorithmReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SetDomainAlgorithmReq) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SetDomainAlgorithmReq) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+type ListDomainActionsRes struct {
+	Actions              []*DomainAction `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListDomainActionsRes) Reset()         { *m = ListDomainActionsRes{} }
+func (m *ListDomainActionsRes) String() string { return proto.CompactTextString(m) }
+func (*ListDomainActionsRes) ProtoMessage()    {}
+func (*ListDomainActionsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{42}
+}
+
+func (m *ListDomainActionsRes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDomainActionsRes.Unmarshal(m, b)
+}
+func (m *ListDomainActionsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDomainActionsRes.Marshal(b, m, deterministic)
+}
+func (m *ListDomainActionsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDomainActionsRes.Merge(m, src)
+}
+func (m *ListDomainActionsRes) XXX_Size() int {
+	return xxx_messageInfo_ListDomainActionsRes.Size(m)
+}
+func (m *ListDomainActionsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDomainActionsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDomainActionsRes proto.InternalMessageInfo
+
+func (m *ListDomainActionsRes) GetActions() []*DomainAction {
+	if m != nil {
+		return m.Actions
+	}
+	return nil
+}
+
+type AddDomainActionReq struct {
+	Token                string        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Action               *DomainAction `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AddDomainActionReq) Reset()         { *m = AddDomainActionReq{} }
+func (m *AddDomainActionReq) String() string { return proto.CompactTextString(m) }
+func (*AddDomainActionReq) ProtoMessage()    {}
+func (*AddDomainActionReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{43}
+}
+
+func (m *AddDomainActionReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddDomainActionReq.Unmarshal(m, b)
+}
+func (m *AddDomainActionReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddDomainActionReq.Marshal(b, m, deterministic)
+}
+func (m *AddDomainActionReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddDomainActionReq.Merge(m, src)
+}
+func (m *AddDomainActionReq) XXX_Size() int {
+	return xxx_messageInfo_AddDomainActionReq.Size(m)
+}
+func (m *AddDomainActionReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddDomainActionReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddDomainActionReq proto.InternalMessageInfo
+
+func (m *AddDomainActionReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AddDomainActionReq) GetAction() *DomainAction {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+type SetAccessExpireReq struct {
+	Token                string        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Expire               *AccessExpire `protobuf:"bytes,2,opt,name=expire,proto3" json:"expire,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SetAccessExpireReq) Reset()         { *m = SetAccessExpireReq{} }
+func (m *SetAccessExpireReq) String() string { return proto.CompactTextString(m) }
+func (*SetAccessExpireReq) ProtoMessage()    {}
+func (*SetAccessExpireReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa45786bafe6da83, []int{44}
+}
+
+func (m *SetAccessExpireReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAccessExpireReq.Unmarshal(m, b)
+}
+func (m *SetAccessExpireReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAccessExpireReq.Marshal(b, m, deterministic)
+}
+func (m *SetAccessExpireReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAccessExpireReq.Merge(m, src)
+}
+func (m *SetAccessExpireReq) XXX_Size() int {
+	return xxx_messageInfo_SetAccessExpireReq.Size(m)
+}
+func (m *SetAccessExpireReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAccessExpireReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAccessExpireReq proto.InternalMessageInfo
+
+func (m *SetAccessExpireReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SetAccessExpireReq) GetExpire() *AccessExpire {
+	if m != nil {
+		return m.Expire
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DomPermReq)(nil), "umg.DomPermReq")
+	proto.RegisterMapType((map[string]string)(nil), "umg.DomPermReq.AttributesEntry")
+	proto.RegisterType((*ProdPermReq)(nil), "umg.ProdPermReq")
+	proto.RegisterMapType((map[string]string)(nil), "umg.ProdPermReq.AttributesEntry")
+	proto.RegisterType((*PropertyPermReq)(nil), "umg.PropertyPermReq")
+	proto.RegisterType((*AddProdReq)(nil), "umg.AddProdReq")
+	proto.RegisterType((*RemProdReq)(nil), "umg.RemProdReq")
+	proto.RegisterType((*AddProdRes)(nil), "umg.AddProdRes")
+	proto.RegisterType((*RemProdRes)(nil), "umg.RemProdRes")
+	proto.RegisterType((*PermRes)(nil), "umg.PermRes")
+	proto.RegisterType((*AddPropertyReq)(nil), "umg.AddPropertyReq")
+	proto.RegisterType((*AddPropertyRes)(nil), "umg.AddPropertyRes")
+	proto.RegisterType((*RemPropertyReq)(nil), "umg.RemPropertyReq")
+	proto.RegisterType((*RemPropertyRes)(nil), "umg.RemPropertyRes")
+	proto.RegisterType((*BatchCheckReq)(nil), "umg.BatchCheckReq")
+	proto.RegisterMapType((map[string]string)(nil), "umg.BatchCheckReq.AttributesEntry")
+	proto.RegisterType((*PermCheck)(nil), "umg.PermCheck")
+	proto.RegisterType((*BatchCheckRes)(nil), "umg.BatchCheckRes")
+	proto.RegisterType((*ListAllowedReq)(nil), "umg.ListAllowedReq")
+	proto.RegisterMapType((map[string]string)(nil), "umg.ListAllowedReq.AttributesEntry")
+	proto.RegisterType((*AllowedProduct)(nil), "umg.AllowedProduct")
+	proto.RegisterType((*ListAllowedRes)(nil), "umg.ListAllowedRes")
+	proto.RegisterType((*User)(nil), "umg.User")
+	proto.RegisterType((*Role)(nil), "umg.Role")
+	proto.RegisterType((*Policy)(nil), "umg.Policy")
+	proto.RegisterType((*Domain)(nil), "umg.Domain")
+	proto.RegisterType((*DomainAction)(nil), "umg.DomainAction")
+	proto.RegisterType((*AccessExpire)(nil), "umg.AccessExpire")
+	proto.RegisterType((*EntityReq)(nil), "umg.EntityReq")
+	proto.RegisterType((*TokenReq)(nil), "umg.TokenReq")
+	proto.RegisterType((*DoneRes)(nil), "umg.DoneRes")
+	proto.RegisterType((*ListReq)(nil), "umg.ListReq")
+	proto.RegisterType((*ListUsersRes)(nil), "umg.ListUsersRes")
+	proto.RegisterType((*AddUserReq)(nil), "umg.AddUserReq")
+	proto.RegisterType((*UpdateUserReq)(nil), "umg.UpdateUserReq")
+	proto.RegisterType((*ListRolesRes)(nil), "umg.ListRolesRes")
+	proto.RegisterType((*AddRoleReq)(nil), "umg.AddRoleReq")
+	proto.RegisterType((*UpdateRoleReq)(nil), "umg.UpdateRoleReq")
+	proto.RegisterType((*RoleAssignmentReq)(nil), "umg.RoleAssignmentReq")
+	proto.RegisterType((*ListRoleUsersReq)(nil), "umg.ListRoleUsersReq")
+	proto.RegisterType((*ListPoliciesReq)(nil), "umg.ListPoliciesReq")
+	proto.RegisterType((*ListPoliciesRes)(nil), "umg.ListPoliciesRes")
+	proto.RegisterType((*AddPolicyReq)(nil), "umg.AddPolicyReq")
+	proto.RegisterType((*ListDomainsRes)(nil), "umg.ListDomainsRes")
+	proto.RegisterType((*AddDomainReq)(nil), "umg.AddDomainReq")
+	proto.RegisterType((*SetDomainAlgorithmReq)(nil), "umg.SetDomainAlgorithmReq")
+	proto.RegisterType((*ListDomainActionsRes)(nil), "umg.ListDomainActionsRes")
+	proto.RegisterType((*AddDomainActionReq)(nil), "umg.AddDomainActionReq")
+	proto.RegisterType((*SetAccessExpireReq)(nil), "umg.SetAccessExpireReq")
+}
+
+func init() { proto.RegisterFile("proto/umg.proto", fileDescriptor_aa45786bafe6da83) }
+
+var fileDescriptor_aa45786bafe6da83 = []byte{
+	// 1871 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x5f, 0x73, 0xdb, 0xc6,
+	0x11, 0x1f, 0x12, 0x14, 0x49, 0x2c, 0xff, 0x59, 0x17, 0x27, 0x41, 0x18, 0xbb, 0xd6, 0xc0, 0xfd,
+	0xa3, 0x34, 0x89, 0x32, 0x62, 0xdd, 0x49, 0xaa, 0x99, 0xba, 0xa5, 0x45, 0x8d, 0xa3, 0x34, 0xed,
+	0x68, 0x60, 0xfb, 0xa5, 0x99, 0x09, 0x07, 0x22, 0xce, 0x14, 0x2a, 0x12, 0x40, 0x70, 0xa0, 0x1c,
+	0x3d, 0xf4, 0x93, 0xf4, 0xa9, 0x5f, 0xa9, 0x9f, 0xa0, 0xd3, 0x97, 0x3e, 0xf7, 0xa5, 0x8f, 0x9d,
+	0xce, 0xde, 0x3f, 0xe0, 0x40, 0x90, 0x89, 0x2c, 0xfb, 0x8d, 0xfb, 0xbb, 0xdd, 0xbb, 0xdf, 0xee,
+	0xde, 0xed, 0xde, 0x81, 0x30, 0x48, 0xd2, 0x38, 0x8b, 0x3f, 0x5b, 0x2d, 0xe7, 0x07, 0xfc, 0x17,
+	0xb1, 0x56, 0xcb, 0xb9, 0xfb, 0xaf, 0x1a, 0xc0, 0x24, 0x5e, 0x9e, 0xd1, 0x74, 0xe9, 0xd1, 0xef,
+	0xc8, 0x5d, 0xd8, 0xc9, 0xe2, 0x4b, 0x1a, 0x39, 0xb5, 0xbd, 0xda, 0xbe, 0xed, 0x09, 0x81, 0xbc,
+	0x07, 0xcd, 0x20, 0x5e, 0xfa, 0x61, 0xe4, 0xd4, 0x39, 0x2c, 0x25, 0xc4, 0xfd, 0x59, 0x16, 0xc6,
+	0x91, 0x63, 0x09, 0x5c, 0x48, 0xa4, 0x0f, 0xf5, 0x30, 0x71, 0x1a, 0x1c, 0xab, 0x87, 0x09, 0xf9,
+	0x1d, 0x80, 0x9f, 0x65, 0x69, 0x78, 0xbe, 0xca, 0x28, 0x73, 0x76, 0xf6, 0xac, 0xfd, 0xce, 0xe8,
+	0xc1, 0x01, 0x32, 0xc9, 0x97, 0x3e, 0x18, 0x6b, 0x8d, 0x93, 0x28, 0x4b, 0xaf, 0xbd, 0x82, 0xc9,
+	0xf0, 0xb7, 0x30, 0x28, 0x0d, 0x93, 0x3b, 0x60, 0x5d, 0xd2, 0x6b, 0xc9, 0x13, 0x7f, 0x22, 0xf7,
+	0x2b, 0x7f, 0xb1, 0xa2, 0x92, 0xa4, 0x10, 0x8e, 0xea, 0x5f, 0xd4, 0xdc, 0xff, 0xd5, 0xa0, 0x73,
+	0x96, 0xc6, 0xc1, 0xeb, 0x79, 0xe9, 0x40, 0x2b, 0x49, 0xe3, 0x60, 0x35, 0xcb, 0xa4, 0x9b, 0x4a,
+	0x2c, 0xf8, 0xdf, 0xa8, 0xf0, 0x7f, 0x47, 0xfb, 0xff, 0x7b, 0xc3, 0xff, 0x26, 0xf7, 0x7f, 0x8f,
+	0xfb, 0x5f, 0x60, 0xf5, 0x36, 0x03, 0xf0, 0x07, 0x18, 0x9c, 0xa5, 0x71, 0x42, 0xd3, 0xec, 0x7a,
+	0x7b, 0x0c, 0x90, 0x79, 0xc0, 0xed, 0x2d, 0xaf, 0x1e, 0x06, 0x84, 0x40, 0x23, 0xbb, 0x4e, 0xa8,
+	0x74, 0x9c, 0xff, 0x76, 0x9f, 0x03, 0x8c, 0x83, 0x00, 0x99, 0xbf, 0xc1, 0x58, 0xe2, 0xac, 0x1e,
+	0x5d, 0xbe, 0xe9, 0x59, 0x8f, 0x0a, 0x5c, 0x19, 0x7a, 0x13, 0xc4, 0x11, 0xe5, 0x93, 0xb6, 0x3d,
+	0xfe, 0x1b, 0x6d, 0x97, 0x94, 0x31, 0x7f, 0xae, 0xc2, 0xa6, 0x44, 0xf7, 0xa8, 0xc0, 0xe8, 0xa6,
+	0xb6, 0x1f, 0x42, 0x4b, 0x04, 0x9a, 0x61, 0x9e, 0x2e, 0x7c, 0x26, 0xed, 0xf0, 0xa7, 0xfb, 0x2d,
+	0xf4, 0x05, 0x29, 0x9e, 0x90, 0x5b, 0x25, 0x03, 0xb1, 0xc8, 0x5f, 0x52, 0xb9, 0x01, 0xf9, 0x6f,
+	0xf7, 0x71, 0x69, 0xfe, 0x9b, 0x92, 0xff, 0x0a, 0xfa, 0xc2, 0xf1, 0xdb, 0xf3, 0x73, 0x1f, 0x97,
+	0xe6, 0xba, 0x29, 0x97, 0x7f, 0xd6, 0xa0, 0xf7, 0xc4, 0xcf, 0x66, 0x17, 0xc7, 0x17, 0x74, 0x76,
+	0xb9, 0x99, 0xcb, 0xcf, 0xa1, 0x39, 0x43, 0x0d, 0xe6, 0xd4, 0xf9, 0xf1, 0xea, 0x8b, 0xe3, 0x45,
+	0xd3, 0xa5, 0x30, 0x94, 0xa3, 0xf2, 0x68, 0x5a, 0xfa, 0x68, 0x3e, 0x31, 0x8e, 0x66, 0x83, 0xdb,
+	0xba, 0xdc, 0xd6, 0x58, 0xf5, 0x6d, 0x1e, 0xce, 0xbf, 0xd5, 0xc0, 0xd6, 0x44, 0x0b, 0x7b, 0xbc,
+	0xb6, 0x69, 0x8f, 0xd7, 0x37, 0x55, 0x21, 0xb3, 0x0a, 0x3f, 0x80, 0x4e, 0x22, 0xe3, 0x3e, 0x0d,
+	0x03, 0xbe, 0x43, 0x2c, 0x0f, 0x14, 0x74, 0x1a, 0x90, 0x87, 0xd0, 0xd3, 0x0a, 0x3c, 0x71, 0xa2,
+	0x62, 0x75, 0x15, 0xf8, 0x1c, 0x13, 0xf8, 0x91, 0x19, 0x7f, 0x86, 0x44, 0x52, 0xca, 0x56, 0x8b,
+	0x0c, 0xf7, 0xb4, 0xb5, 0xdf, 0xf6, 0x94, 0xe8, 0xfe, 0xa3, 0x06, 0xfd, 0xaf, 0x43, 0x96, 0x8d,
+	0x17, 0x8b, 0xf8, 0x15, 0x7d, 0x8d, 0x73, 0x5c, 0x4e, 0xce, 0x71, 0x45, 0x72, 0x1e, 0xf2, 0xe4,
+	0x98, 0xcb, 0xbc, 0xcd, 0xec, 0x4c, 0xa0, 0x2f, 0x17, 0x3a, 0x93, 0xf1, 0x2e, 0x64, 0xa2, 0x66,
+	0x66, 0xc2, 0x81, 0x96, 0x88, 0xbd, 0xd8, 0x85, 0xb6, 0xa7, 0x44, 0xf7, 0xa2, 0x14, 0x19, 0x46,
+	0x7e, 0x06, 0x7d, 0xe1, 0xf5, 0x54, 0x99, 0xd4, 0xb8, 0x49, 0x4f, 0xa0, 0x63, 0x01, 0x92, 0xcf,
+	0xa0, 0x2d, 0x67, 0x57, 0x3b, 0xfb, 0x1d, 0x1e, 0x00, 0x93, 0x93, 0xa7, 0x95, 0xdc, 0xff, 0xd6,
+	0xa1, 0xf1, 0x82, 0xd1, 0x54, 0x9e, 0xce, 0x9a, 0x3e, 0x9d, 0x43, 0x68, 0xaf, 0x18, 0x4d, 0x79,
+	0xb5, 0x10, 0x5e, 0x6a, 0x19, 0xc7, 0x12, 0x9f, 0xb1, 0x57, 0x71, 0x1a, 0xc8, 0xf0, 0x6b, 0x19,
+	0x43, 0x43, 0x97, 0x7e, 0xb8, 0x90, 0x25, 0x46, 0x08, 0xba, 0xee, 0xec, 0xe4, 0x75, 0x07, 0xdd,
+	0x9f, 0xc5, 0xcb, 0xc4, 0x8f, 0xae, 0x9d, 0xa6, 0x08, 0x8c, 0x14, 0x71, 0xe4, 0x15, 0x3d, 0x67,
+	0x61, 0x46, 0x9d, 0x96, 0x18, 0x91, 0x22, 0xae, 0xec, 0x07, 0x41, 0x4a, 0x19, 0x3b, 0x74, 0xda,
+	0x62, 0x65, 0x25, 0x17, 0xc6, 0x46, 0x8e, 0x6d, 0x8c, 0x8d, 0x70, 0x0b, 0x25, 0x17, 0x71, 0x44,
+	0x0f, 0x1d, 0x10, 0x5b, 0x48, 0x48, 0x1a, 0x1f, 0x39, 0x9d, 0x02, 0x3e, 0x42, 0xbe, 0x2f, 0xfd,
+	0xef, 0x0f, 0x9d, 0xae, 0xe0, 0x8b, 0xbf, 0x25, 0x36, 0x72, 0x7a, 0x1a, 0x1b, 0x91, 0x0f, 0xa0,
+	0x9d, 0xc6, 0x0b, 0x3a, 0x0d, 0x03, 0xe6, 0xf4, 0xf7, 0xac, 0x7d, 0xcb, 0x6b, 0xa1, 0x7c, 0x1a,
+	0x30, 0x72, 0x1f, 0x60, 0xe1, 0xb3, 0x6c, 0xba, 0x88, 0xe7, 0x61, 0xe4, 0x0c, 0xb8, 0x91, 0x8d,
+	0xc8, 0xd7, 0x08, 0xb8, 0xa7, 0xd0, 0xf0, 0xe2, 0x05, 0x5d, 0x8b, 0xbb, 0x8a, 0x54, 0xbd, 0x10,
+	0xa9, 0xfb, 0x00, 0x89, 0x9f, 0xd2, 0x28, 0xe3, 0xeb, 0x58, 0x7c, 0x1d, 0x5b, 0x20, 0xa7, 0x01,
+	0x73, 0xff, 0x53, 0x83, 0xe6, 0x59, 0xbc, 0x08, 0x67, 0xd7, 0x6b, 0xb3, 0xbd, 0x0f, 0x2d, 0xc9,
+	0x4f, 0x16, 0xde, 0xa6, 0xa0, 0x57, 0xd9, 0x1c, 0x0a, 0xfb, 0xb1, 0x61, 0xec, 0x47, 0xf2, 0x13,
+	0x50, 0x85, 0x20, 0x94, 0x37, 0xb2, 0xbc, 0x34, 0x84, 0x94, 0xfb, 0x2a, 0x77, 0x14, 0xae, 0xd4,
+	0xe4, 0x2b, 0xd9, 0x12, 0x39, 0x0d, 0xc8, 0x87, 0x60, 0xcb, 0xcd, 0x1b, 0x06, 0x3c, 0xa3, 0x96,
+	0xd7, 0x16, 0xc0, 0x69, 0x80, 0x29, 0xa0, 0x2f, 0x5f, 0xd2, 0x59, 0x26, 0x13, 0x2a, 0x25, 0x72,
+	0x0f, 0xec, 0x59, 0x1c, 0x05, 0x21, 0x2f, 0x55, 0x22, 0x9f, 0x39, 0xe0, 0x7e, 0x05, 0xcd, 0x49,
+	0x5e, 0x05, 0x7e, 0x28, 0x80, 0xf7, 0xc0, 0xf6, 0x17, 0xf3, 0x38, 0x0d, 0xb3, 0x8b, 0xa5, 0x74,
+	0x39, 0x07, 0xdc, 0xef, 0xa0, 0x3b, 0x29, 0x9c, 0xa2, 0xb5, 0x19, 0x0d, 0xfa, 0xf5, 0x12, 0x7d,
+	0xb5, 0x9c, 0x55, 0x58, 0x6e, 0x0f, 0x3a, 0x01, 0x65, 0xb3, 0x34, 0x4c, 0x0a, 0xb7, 0xbd, 0x22,
+	0xe4, 0x4e, 0xa0, 0x3b, 0x9e, 0xcd, 0x28, 0x63, 0x27, 0xdf, 0x27, 0x61, 0x4a, 0x31, 0x4f, 0x78,
+	0xba, 0xa6, 0x7a, 0xdd, 0x26, 0x8a, 0x22, 0x74, 0x94, 0xab, 0x4c, 0x7d, 0x55, 0xc9, 0xdb, 0x02,
+	0x18, 0x67, 0xee, 0x21, 0xd8, 0x27, 0x51, 0x16, 0xde, 0xa0, 0xe9, 0xba, 0x7b, 0xd0, 0x7e, 0x8e,
+	0x03, 0x1b, 0x2d, 0xdc, 0x4f, 0xa1, 0x35, 0x89, 0x23, 0xba, 0xa9, 0xf7, 0x96, 0x27, 0x64, 0xd0,
+	0xc2, 0x52, 0xb5, 0x99, 0xc1, 0x5d, 0xd8, 0x99, 0xc5, 0xab, 0x28, 0x93, 0x36, 0x42, 0xc0, 0xa9,
+	0x13, 0x7f, 0x2e, 0xc2, 0x66, 0x79, 0xfc, 0x37, 0x6a, 0xc6, 0x69, 0x40, 0x53, 0x55, 0x3a, 0xb8,
+	0x80, 0x9a, 0x2c, 0x4e, 0x33, 0x55, 0x3a, 0xf0, 0xb7, 0xfb, 0x27, 0xe8, 0xe2, 0xa2, 0x58, 0xb8,
+	0x18, 0x12, 0x7d, 0x00, 0x3b, 0x18, 0x2f, 0x51, 0x14, 0x3b, 0x23, 0x9b, 0xd7, 0x3c, 0x1c, 0xf5,
+	0x04, 0x2e, 0x4e, 0xd0, 0x9c, 0x4e, 0x8b, 0x4c, 0x6c, 0x44, 0x8e, 0x11, 0x70, 0xff, 0xca, 0xef,
+	0x7d, 0xdc, 0x60, 0xa3, 0x1f, 0xf7, 0xa1, 0x81, 0x73, 0x71, 0x63, 0x63, 0x09, 0x0e, 0xe3, 0x0a,
+	0x8c, 0x46, 0xc1, 0x54, 0x14, 0x3f, 0x8b, 0x47, 0xcc, 0x46, 0xe4, 0x04, 0x01, 0x33, 0x8f, 0x8d,
+	0x52, 0x1e, 0x27, 0xd0, 0x7b, 0x91, 0x04, 0x7e, 0x46, 0x6f, 0xc3, 0x40, 0x05, 0x05, 0xab, 0x8a,
+	0x0a, 0x0a, 0x1e, 0x76, 0x33, 0x28, 0x38, 0xea, 0x09, 0xfc, 0x87, 0x82, 0xf2, 0x17, 0x1e, 0x14,
+	0x6e, 0xb0, 0x8d, 0x12, 0xce, 0x65, 0x50, 0xe2, 0x16, 0x1c, 0x26, 0xbf, 0x80, 0x76, 0x82, 0x85,
+	0x29, 0xa4, 0xa2, 0x6c, 0x75, 0x46, 0x1d, 0x71, 0xd1, 0xe2, 0xd5, 0xca, 0xd3, 0x83, 0x6e, 0xa8,
+	0x22, 0x70, 0xab, 0xe5, 0x1e, 0x42, 0x6f, 0xb6, 0xa0, 0x7e, 0x3a, 0x15, 0xb5, 0x91, 0xc9, 0x34,
+	0x74, 0x39, 0x78, 0x26, 0x30, 0xf7, 0x1b, 0xd8, 0x45, 0x93, 0x31, 0x63, 0xe1, 0x3c, 0x5a, 0xd2,
+	0x68, 0xcb, 0xd6, 0x2d, 0x9c, 0xca, 0xba, 0x71, 0x2a, 0x0b, 0x65, 0xd5, 0x2a, 0x96, 0x55, 0xf7,
+	0x12, 0xee, 0xa8, 0x1c, 0xc8, 0xcd, 0xb9, 0x65, 0xee, 0xea, 0xca, 0xac, 0xcf, 0x8b, 0x55, 0x75,
+	0x5e, 0x1a, 0xf9, 0x79, 0x71, 0xbf, 0x85, 0x01, 0x2e, 0x76, 0x26, 0x83, 0xf8, 0x1a, 0x6b, 0xdd,
+	0x03, 0x5b, 0x54, 0xdb, 0xf0, 0x8a, 0xaa, 0x3d, 0xab, 0x01, 0xf7, 0xa8, 0x3c, 0x3f, 0x33, 0x12,
+	0x5a, 0xdb, 0x96, 0xd0, 0x53, 0xe8, 0xe2, 0xa3, 0x42, 0xc0, 0x1b, 0x89, 0x3d, 0x84, 0x26, 0xb7,
+	0xb8, 0x96, 0x19, 0x35, 0x26, 0x93, 0x43, 0xee, 0xe7, 0xe2, 0x32, 0x24, 0x4a, 0x34, 0x13, 0x97,
+	0xa1, 0x96, 0xa8, 0xbf, 0x26, 0x09, 0xa1, 0xe1, 0xa9, 0x31, 0xc9, 0x41, 0xa2, 0xdb, 0x38, 0x14,
+	0x6e, 0x97, 0xa5, 0xb9, 0xe4, 0x90, 0xfb, 0x0d, 0xbc, 0xfb, 0x8c, 0x4a, 0x0a, 0x63, 0xd5, 0x38,
+	0x7e, 0xfc, 0x53, 0x67, 0x7b, 0xff, 0x39, 0x86, 0xbb, 0xb9, 0x83, 0xf2, 0x26, 0x87, 0x6e, 0x7e,
+	0x9c, 0xf7, 0x63, 0xe1, 0xe6, 0x6e, 0x81, 0x9a, 0xd0, 0xcb, 0xaf, 0x8c, 0x2f, 0x80, 0x68, 0x67,
+	0xe5, 0xd8, 0x46, 0x7a, 0x1f, 0xe9, 0x27, 0x80, 0x70, 0xb9, 0x62, 0x5e, 0xa9, 0x80, 0xd3, 0x3e,
+	0xa3, 0x59, 0xb1, 0x57, 0x6d, 0x9d, 0x56, 0x94, 0x34, 0x63, 0x5a, 0xc3, 0x56, 0x2a, 0x8c, 0xfe,
+	0x6d, 0x41, 0x67, 0xbc, 0xca, 0x2e, 0x9e, 0xd1, 0xf4, 0x2a, 0x9c, 0x51, 0xf2, 0x31, 0xc0, 0x97,
+	0x3e, 0x93, 0x9f, 0x77, 0xc8, 0xa0, 0xf4, 0xb1, 0x67, 0xd8, 0xd5, 0xcf, 0x33, 0x8c, 0xcb, 0xa7,
+	0xd0, 0xf9, 0xd2, 0x67, 0xea, 0x5b, 0x08, 0xb9, 0x53, 0xfe, 0x34, 0x52, 0x52, 0xff, 0x35, 0x0c,
+	0x84, 0xba, 0xfe, 0xa0, 0x41, 0xee, 0x2a, 0x93, 0xe2, 0x37, 0x8e, 0x92, 0xd9, 0x23, 0x80, 0xfc,
+	0x25, 0x43, 0xc8, 0xfa, 0x23, 0x6f, 0xb8, 0x8e, 0x31, 0xf2, 0x39, 0x74, 0x0a, 0x37, 0x77, 0xf2,
+	0x4e, 0xc5, 0xf3, 0x63, 0x58, 0x01, 0x32, 0x72, 0xa0, 0x3f, 0x3d, 0xe0, 0xd3, 0x40, 0x44, 0x20,
+	0xff, 0x6e, 0x32, 0x2c, 0x01, 0x8c, 0x1c, 0x42, 0xcf, 0xa3, 0xcb, 0xf8, 0x8a, 0x9a, 0x26, 0xf9,
+	0x47, 0x91, 0x61, 0x09, 0xe0, 0xdc, 0x0a, 0x0f, 0x7d, 0xc9, 0xcd, 0xfc, 0xb4, 0x30, 0xac, 0x00,
+	0x19, 0x39, 0x82, 0xbe, 0x5e, 0xab, 0x68, 0x6b, 0x3e, 0xfb, 0x87, 0x15, 0x20, 0x1b, 0xfd, 0x1d,
+	0x60, 0xf7, 0x8f, 0x7e, 0xe4, 0xcf, 0x29, 0xd6, 0x5a, 0x95, 0xef, 0x9f, 0x42, 0xeb, 0x29, 0xcd,
+	0xc4, 0xc3, 0x83, 0x5b, 0xe9, 0x7b, 0xcc, 0x30, 0xef, 0x6b, 0xe4, 0x13, 0xb0, 0x75, 0x9b, 0x27,
+	0x5d, 0x1d, 0x35, 0xd4, 0xda, 0xd5, 0x92, 0xbe, 0x04, 0xec, 0x43, 0x4b, 0x36, 0xf1, 0x3c, 0x7c,
+	0xb2, 0xa1, 0xca, 0xd4, 0xaa, 0x7b, 0xcd, 0x01, 0x40, 0xde, 0x6f, 0x65, 0x6a, 0x8d, 0x06, 0x5c,
+	0xd2, 0xff, 0x25, 0xff, 0xb4, 0x13, 0x5f, 0xd1, 0x4a, 0xc2, 0xa6, 0xae, 0xf0, 0x4c, 0x5c, 0xed,
+	0x2b, 0x3d, 0xe3, 0x43, 0xd2, 0x33, 0x8f, 0xf7, 0xe1, 0x4d, 0x9e, 0xe9, 0x4e, 0x2e, 0x3c, 0xe3,
+	0x86, 0xda, 0x33, 0xd9, 0x28, 0x37, 0x79, 0xc6, 0x95, 0x8b, 0x9e, 0x55, 0xeb, 0x6b, 0xcf, 0x2a,
+	0x09, 0x9b, 0xba, 0x23, 0x00, 0xd1, 0x34, 0xb9, 0xee, 0x7b, 0xda, 0x19, 0xa3, 0x93, 0x96, 0x6c,
+	0x1e, 0x41, 0x77, 0x12, 0x32, 0x1f, 0xb7, 0xf9, 0x0d, 0xac, 0x7e, 0x03, 0x3d, 0xa3, 0x8b, 0x92,
+	0x77, 0x8d, 0x98, 0xa8, 0xce, 0x5a, 0xb5, 0x09, 0x8e, 0xc4, 0x25, 0x48, 0xf5, 0x2c, 0x79, 0xd2,
+	0x4b, 0x6d, 0x72, 0x58, 0x85, 0x32, 0x4c, 0x8a, 0xee, 0x59, 0x64, 0x57, 0x1f, 0x04, 0xd5, 0xc3,
+	0x4a, 0x24, 0x3f, 0x81, 0xae, 0x3c, 0x14, 0xf2, 0xe9, 0xb5, 0x35, 0x78, 0xfb, 0x60, 0x3f, 0x55,
+	0x0d, 0x64, 0x4d, 0xb5, 0xd8, 0x72, 0xc8, 0xa1, 0xa8, 0x20, 0x42, 0x62, 0xa4, 0xc7, 0xc7, 0xd4,
+	0x9d, 0xbd, 0x50, 0x3b, 0x0a, 0xfd, 0x50, 0x10, 0x97, 0xf6, 0x9a, 0xb8, 0x6e, 0x7c, 0x25, 0x2a,
+	0x8f, 0x79, 0x49, 0x2f, 0xf5, 0x32, 0x32, 0xe4, 0x3a, 0x95, 0x4d, 0x6e, 0xcd, 0x7e, 0x77, 0xad,
+	0x5d, 0xad, 0xb9, 0xf4, 0x41, 0x89, 0x67, 0xa1, 0xad, 0x7d, 0x01, 0x83, 0x52, 0xa7, 0x22, 0xef,
+	0x9b, 0x9c, 0x75, 0xff, 0x5a, 0xdb, 0x81, 0x44, 0x84, 0xdc, 0x7c, 0xae, 0x6d, 0x0d, 0xfc, 0x23,
+	0x18, 0x3c, 0x35, 0x1b, 0xd8, 0x9a, 0xc1, 0x7a, 0x9f, 0x42, 0x8e, 0xa5, 0xb6, 0x27, 0x39, 0xae,
+	0x37, 0xc3, 0x4d, 0x1c, 0xb7, 0x2e, 0x69, 0xd8, 0x3c, 0x69, 0xfc, 0xb9, 0x9e, 0x9c, 0x9f, 0x37,
+	0xf9, 0xff, 0x2c, 0xbf, 0xfa, 0xff, 0x00, 0x95, 0x8e, 0x52, 0xc4, 0x7a, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	HasDomPerm(ctx context.Context, in *DomPermReq, opts ...grpc.CallOption) (*PermRes, error)
+	HasProdPerm(ctx context.Context, in *ProdPermReq, opts ...grpc.CallOption) (*PermRes, error)
+	HasPropertyPerm(ctx context.Context, in *PropertyPermReq, opts ...grpc.CallOption) (*PermRes, error)
+	BatchCheck(ctx context.Context, in *BatchCheckReq, opts ...grpc.CallOption) (*BatchCheckRes, error)
+	ListAllowed(ctx context.Context, in *ListAllowedReq, opts ...grpc.CallOption) (*ListAllowedRes, error)
+	AddProduct(ctx context.Context, in *AddProdReq, opts ...grpc.CallOption) (*AddProdRes, error)
+	RemoveProduct(ctx context.Context, in *RemProdReq, opts ...grpc.CallOption) (*RemProdRes, error)
+	AddProperty(ctx context.Context, in *AddPropertyReq, opts ...grpc.CallOption) (*AddPropertyRes, error)
+	RemoveProperty(ctx context.Context, in *RemPropertyReq, opts ...grpc.CallOption) (*RemPropertyRes, error)
+}
+
+type authServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuthServiceClient(cc *grpc.ClientConn) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) HasDomPerm(ctx context.Context, in *DomPermReq, opts ...grpc.CallOption) (*PermRes, error) {
+	out := new(PermRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/HasDomPerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) HasProdPerm(ctx context.Context, in *ProdPermReq, opts ...grpc.CallOption) (*PermRes, error) {
+	out := new(PermRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/HasProdPerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) HasPropertyPerm(ctx context.Context, in *PropertyPermReq, opts ...grpc.CallOption) (*PermRes, error) {
+	out := new(PermRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/HasPropertyPerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) BatchCheck(ctx context.Context, in *BatchCheckReq, opts ...grpc.CallOption) (*BatchCheckRes, error) {
+	out := new(BatchCheckRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/BatchCheck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListAllowed(ctx context.Context, in *ListAllowedReq, opts ...grpc.CallOption) (*ListAllowedRes, error) {
+	out := new(ListAllowedRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/ListAllowed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AddProduct(ctx context.Context, in *AddProdReq, opts ...grpc.CallOption) (*AddProdRes, error) {
+	out := new(AddProdRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/AddProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RemoveProduct(ctx context.Context, in *RemProdReq, opts ...grpc.CallOption) (*RemProdRes, error) {
+	out := new(RemProdRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/RemoveProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AddProperty(ctx context.Context, in *AddPropertyReq, opts ...grpc.CallOption) (*AddPropertyRes, error) {
+	out := new(AddPropertyRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/AddProperty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RemoveProperty(ctx context.Context, in *RemPropertyReq, opts ...grpc.CallOption) (*RemPropertyRes, error) {
+	out := new(RemPropertyRes)
+	err := c.cc.Invoke(ctx, "/umg.AuthService/RemoveProperty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+type AuthServiceServer interface {
+	HasDomPerm(context.Context, *DomPermReq) (*PermRes, error)
+	HasProdPerm(context.Context, *ProdPermReq) (*PermRes, error)
+	HasPropertyPerm(context.Context, *PropertyPermReq) (*PermRes, error)
+	BatchCheck(context.Context, *BatchCheckReq) (*BatchCheckRes, error)
+	ListAllowed(context.Context, *ListAllowedReq) (*ListAllowedRes, error)
+	AddProduct(context.Context, *AddProdReq) (*AddProdRes, error)
+	RemoveProduct(context.Context, *RemProdReq) (*RemProdRes, error)
+	AddProperty(context.Context, *AddPropertyReq) (*AddPropertyRes, error)
+	RemoveProperty(context.Context, *RemPropertyReq) (*RemPropertyRes, error)
+}
+
+// UnimplementedAuthServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAuthServiceServer struct {
+}
+
+func (*UnimplementedAuthServiceServer) HasDomPerm(ctx context.Context, req *DomPermReq) (*PermRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasDomPerm not implemented")
+}
+func (*UnimplementedAuthServiceServer) HasProdPerm(ctx context.Context, req *ProdPermReq) (*PermRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasProdPerm not implemented")
+}
+func (*UnimplementedAuthServiceServer) HasPropertyPerm(ctx context.Context, req *PropertyPermReq) (*PermRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasPropertyPerm not implemented")
+}
+func (*UnimplementedAuthServiceServer) BatchCheck(ctx context.Context, req *BatchCheckReq) (*BatchCheckRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCheck not implemented")
+}
+func (*UnimplementedAuthServiceServer) ListAllowed(ctx context.Context, req *ListAllowedReq) (*ListAllowedRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllowed not implemented")
+}
+func (*UnimplementedAuthServiceServer) AddProduct(ctx context.Context, req *AddProdReq) (*AddProdRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProduct not implemented")
+}
+func (*UnimplementedAuthServiceServer) RemoveProduct(ctx context.Context, req *RemProdReq) (*RemProdRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProduct not implemented")
+}
+func (*UnimplementedAuthServiceServer) AddProperty(ctx context.Context, req *AddPropertyReq) (*AddPropertyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddProperty not implemented")
+}
+func (*UnimplementedAuthServiceServer) RemoveProperty(ctx context.Context, req *RemPropertyReq) (*RemPropertyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveProperty not implemented")
+}
+
+func RegisterAuthServiceServer(s *grpc.Server, srv AuthServiceServer) {
+	s.RegisterService(&_AuthService_serviceDesc, srv)
+}
+
+func _AuthService_HasDomPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DomPermReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).HasDomPerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/HasDomPerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).HasDomPerm(ctx, req.(*DomPermReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_HasProdPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProdPermReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).HasProdPerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/HasProdPerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).HasProdPerm(ctx, req.(*ProdPermReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_HasPropertyPerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PropertyPermReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).HasPropertyPerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/HasPropertyPerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).HasPropertyPerm(ctx, req.(*PropertyPermReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_BatchCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCheckReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).BatchCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/BatchCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).BatchCheck(ctx, req.(*BatchCheckReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListAllowed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllowedReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAllowed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/ListAllowed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAllowed(ctx, req.(*ListAllowedReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
 		FullMethod: "/umg.AuthService/AddProduct",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).AddProduct(ctx, req.(*AddProdReq))
+		return srv.(AuthServiceServer).AddProduct(ctx, req.(*AddProdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RemoveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemProdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RemoveProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/RemoveProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RemoveProduct(ctx, req.(*RemProdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPropertyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/AddProperty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddProperty(ctx, req.(*AddPropertyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RemoveProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemPropertyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RemoveProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.AuthService/RemoveProperty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RemoveProperty(ctx, req.(*RemPropertyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AuthService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "umg.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HasDomPerm",
+			Handler:    _AuthService_HasDomPerm_Handler,
+		},
+		{
+			MethodName: "HasProdPerm",
+			Handler:    _AuthService_HasProdPerm_Handler,
+		},
+		{
+			MethodName: "HasPropertyPerm",
+			Handler:    _AuthService_HasPropertyPerm_Handler,
+		},
+		{
+			MethodName: "BatchCheck",
+			Handler:    _AuthService_BatchCheck_Handler,
+		},
+		{
+			MethodName: "ListAllowed",
+			Handler:    _AuthService_ListAllowed_Handler,
+		},
+		{
+			MethodName: "AddProduct",
+			Handler:    _AuthService_AddProduct_Handler,
+		},
+		{
+			MethodName: "RemoveProduct",
+			Handler:    _AuthService_RemoveProduct_Handler,
+		},
+		{
+			MethodName: "AddProperty",
+			Handler:    _AuthService_AddProperty_Handler,
+		},
+		{
+			MethodName: "RemoveProperty",
+			Handler:    _AuthService_RemoveProperty_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/umg.proto",
+}
+
+// ManagementServiceClient is the client API for ManagementService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ManagementServiceClient interface {
+	GetUser(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*User, error)
+	ListUsers(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListUsersRes, error)
+	AddUser(ctx context.Context, in *AddUserReq, opts ...grpc.CallOption) (*DoneRes, error)
+	UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*DoneRes, error)
+	RemoveUser(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error)
+	GetRole(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Role, error)
+	ListRoles(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListRolesRes, error)
+	AddRole(ctx context.Context, in *AddRoleReq, opts ...grpc.CallOption) (*DoneRes, error)
+	UpdateRole(ctx context.Context, in *UpdateRoleReq, opts ...grpc.CallOption) (*DoneRes, error)
+	RemoveRole(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error)
+	AssignRole(ctx context.Context, in *RoleAssignmentReq, opts ...grpc.CallOption) (*DoneRes, error)
+	DisallowRole(ctx context.Context, in *RoleAssignmentReq, opts ...grpc.CallOption) (*DoneRes, error)
+	ListRoleUsers(ctx context.Context, in *ListRoleUsersReq, opts ...grpc.CallOption) (*ListUsersRes, error)
+	ListPolicies(ctx context.Context, in *ListPoliciesReq, opts ...grpc.CallOption) (*ListPoliciesRes, error)
+	AddPolicy(ctx context.Context, in *AddPolicyReq, opts ...grpc.CallOption) (*DoneRes, error)
+	RemovePolicy(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error)
+	GetDomain(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Domain, error)
+	ListDomains(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*ListDomainsRes, error)
+	AddDomain(ctx context.Context, in *AddDomainReq, opts ...grpc.CallOption) (*DoneRes, error)
+	SetDomainAlgorithm(ctx context.Context, in *SetDomainAlgorithmReq, opts ...grpc.CallOption) (*DoneRes, error)
+	ListDomainActions(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*ListDomainActionsRes, error)
+	AddDomainAction(ctx context.Context, in *AddDomainActionReq, opts ...grpc.CallOption) (*DoneRes, error)
+	RemoveDomainAction(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error)
+	GetAccessExpire(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*AccessExpire, error)
+	SetAccessExpire(ctx context.Context, in *SetAccessExpireReq, opts ...grpc.CallOption) (*DoneRes, error)
+	RemoveAccessExpire(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error)
+}
+
+type managementServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewManagementServiceClient(cc *grpc.ClientConn) ManagementServiceClient {
+	return &managementServiceClient{cc}
+}
+
+func (c *managementServiceClient) GetUser(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListUsers(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListUsersRes, error) {
+	out := new(ListUsersRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AddUser(ctx context.Context, in *AddUserReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AddUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RemoveUser(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/RemoveUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetRole(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/GetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListRoles(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListRolesRes, error) {
+	out := new(ListRolesRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AddRole(ctx context.Context, in *AddRoleReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AddRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) UpdateRole(ctx context.Context, in *UpdateRoleReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/UpdateRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RemoveRole(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/RemoveRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AssignRole(ctx context.Context, in *RoleAssignmentReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AssignRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) DisallowRole(ctx context.Context, in *RoleAssignmentReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/DisallowRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListRoleUsers(ctx context.Context, in *ListRoleUsersReq, opts ...grpc.CallOption) (*ListUsersRes, error) {
+	out := new(ListUsersRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListRoleUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListPolicies(ctx context.Context, in *ListPoliciesReq, opts ...grpc.CallOption) (*ListPoliciesRes, error) {
+	out := new(ListPoliciesRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AddPolicy(ctx context.Context, in *AddPolicyReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AddPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RemovePolicy(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/RemovePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetDomain(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*Domain, error) {
+	out := new(Domain)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/GetDomain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListDomains(ctx context.Context, in *TokenReq, opts ...grpc.CallOption) (*ListDomainsRes, error) {
+	out := new(ListDomainsRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListDomains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AddDomain(ctx context.Context, in *AddDomainReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AddDomain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) SetDomainAlgorithm(ctx context.Context, in *SetDomainAlgorithmReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/SetDomainAlgorithm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListDomainActions(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*ListDomainActionsRes, error) {
+	out := new(ListDomainActionsRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/ListDomainActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) AddDomainAction(ctx context.Context, in *AddDomainActionReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/AddDomainAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RemoveDomainAction(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/RemoveDomainAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetAccessExpire(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*AccessExpire, error) {
+	out := new(AccessExpire)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/GetAccessExpire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) SetAccessExpire(ctx context.Context, in *SetAccessExpireReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/SetAccessExpire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RemoveAccessExpire(ctx context.Context, in *EntityReq, opts ...grpc.CallOption) (*DoneRes, error) {
+	out := new(DoneRes)
+	err := c.cc.Invoke(ctx, "/umg.ManagementService/RemoveAccessExpire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ManagementServiceServer is the server API for ManagementService service.
+type ManagementServiceServer interface {
+	GetUser(context.Context, *EntityReq) (*User, error)
+	ListUsers(context.Context, *ListReq) (*ListUsersRes, error)
+	AddUser(context.Context, *AddUserReq) (*DoneRes, error)
+	UpdateUser(context.Context, *UpdateUserReq) (*DoneRes, error)
+	RemoveUser(context.Context, *EntityReq) (*DoneRes, error)
+	GetRole(context.Context, *EntityReq) (*Role, error)
+	ListRoles(context.Context, *ListReq) (*ListRolesRes, error)
+	AddRole(context.Context, *AddRoleReq) (*DoneRes, error)
+	UpdateRole(context.Context, *UpdateRoleReq) (*DoneRes, error)
+	RemoveRole(context.Context, *EntityReq) (*DoneRes, error)
+	AssignRole(context.Context, *RoleAssignmentReq) (*DoneRes, error)
+	DisallowRole(context.Context, *RoleAssignmentReq) (*DoneRes, error)
+	ListRoleUsers(context.Context, *ListRoleUsersReq) (*ListUsersRes, error)
+	ListPolicies(context.Context, *ListPoliciesReq) (*ListPoliciesRes, error)
+	AddPolicy(context.Context, *AddPolicyReq) (*DoneRes, error)
+	RemovePolicy(context.Context, *EntityReq) (*DoneRes, error)
+	GetDomain(context.Context, *EntityReq) (*Domain, error)
+	ListDomains(context.Context, *TokenReq) (*ListDomainsRes, error)
+	AddDomain(context.Context, *AddDomainReq) (*DoneRes, error)
+	SetDomainAlgorithm(context.Context, *SetDomainAlgorithmReq) (*DoneRes, error)
+	ListDomainActions(context.Context, *EntityReq) (*ListDomainActionsRes, error)
+	AddDomainAction(context.Context, *AddDomainActionReq) (*DoneRes, error)
+	RemoveDomainAction(context.Context, *EntityReq) (*DoneRes, error)
+	GetAccessExpire(context.Context, *EntityReq) (*AccessExpire, error)
+	SetAccessExpire(context.Context, *SetAccessExpireReq) (*DoneRes, error)
+	RemoveAccessExpire(context.Context, *EntityReq) (*DoneRes, error)
+}
+
+// UnimplementedManagementServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedManagementServiceServer struct {
+}
+
+func (*UnimplementedManagementServiceServer) GetUser(ctx context.Context, req *EntityReq) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListUsers(ctx context.Context, req *ListReq) (*ListUsersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (*UnimplementedManagementServiceServer) AddUser(ctx context.Context, req *AddUserReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUser not implemented")
+}
+func (*UnimplementedManagementServiceServer) UpdateUser(ctx context.Context, req *UpdateUserReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedManagementServiceServer) RemoveUser(ctx context.Context, req *EntityReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+}
+func (*UnimplementedManagementServiceServer) GetRole(ctx context.Context, req *EntityReq) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListRoles(ctx context.Context, req *ListReq) (*ListRolesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (*UnimplementedManagementServiceServer) AddRole(ctx context.Context, req *AddRoleReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) UpdateRole(ctx context.Context, req *UpdateRoleReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) RemoveRole(ctx context.Context, req *EntityReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) AssignRole(ctx context.Context, req *RoleAssignmentReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) DisallowRole(ctx context.Context, req *RoleAssignmentReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisallowRole not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListRoleUsers(ctx context.Context, req *ListRoleUsersReq) (*ListUsersRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleUsers not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListPolicies(ctx context.Context, req *ListPoliciesReq) (*ListPoliciesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolicies not implemented")
+}
+func (*UnimplementedManagementServiceServer) AddPolicy(ctx context.Context, req *AddPolicyReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPolicy not implemented")
+}
+func (*UnimplementedManagementServiceServer) RemovePolicy(ctx context.Context, req *EntityReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePolicy not implemented")
+}
+func (*UnimplementedManagementServiceServer) GetDomain(ctx context.Context, req *EntityReq) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListDomains(ctx context.Context, req *TokenReq) (*ListDomainsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomains not implemented")
+}
+func (*UnimplementedManagementServiceServer) AddDomain(ctx context.Context, req *AddDomainReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDomain not implemented")
+}
+func (*UnimplementedManagementServiceServer) SetDomainAlgorithm(ctx context.Context, req *SetDomainAlgorithmReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDomainAlgorithm not implemented")
+}
+func (*UnimplementedManagementServiceServer) ListDomainActions(ctx context.Context, req *EntityReq) (*ListDomainActionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomainActions not implemented")
+}
+func (*UnimplementedManagementServiceServer) AddDomainAction(ctx context.Context, req *AddDomainActionReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDomainAction not implemented")
+}
+func (*UnimplementedManagementServiceServer) RemoveDomainAction(ctx context.Context, req *EntityReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDomainAction not implemented")
+}
+func (*UnimplementedManagementServiceServer) GetAccessExpire(ctx context.Context, req *EntityReq) (*AccessExpire, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessExpire not implemented")
+}
+func (*UnimplementedManagementServiceServer) SetAccessExpire(ctx context.Context, req *SetAccessExpireReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccessExpire not implemented")
+}
+func (*UnimplementedManagementServiceServer) RemoveAccessExpire(ctx context.Context, req *EntityReq) (*DoneRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAccessExpire not implemented")
+}
+
+func RegisterManagementServiceServer(s *grpc.Server, srv ManagementServiceServer) {
+	s.RegisterService(&_ManagementService_serviceDesc, srv)
+}
+
+func _ManagementService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetUser(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListUsers(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).AddUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/AddUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).AddUser(ctx, req.(*AddUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).UpdateUser(ctx, req.(*UpdateUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/RemoveUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).RemoveUser(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/GetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetRole(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListRoles(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_AddRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).AddRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/AddRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).AddRole(ctx, req.(*AddRoleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_UpdateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).UpdateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/UpdateRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).UpdateRole(ctx, req.(*UpdateRoleReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_RemoveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemProdReq)
+func _ManagementService_RemoveRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).RemoveProduct(ctx, in)
+		return srv.(ManagementServiceServer).RemoveRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/umg.AuthService/RemoveProduct",
+		FullMethod: "/umg.ManagementService/RemoveRole",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).RemoveProduct(ctx, req.(*RemProdReq))
+		return srv.(ManagementServiceServer).RemoveRole(ctx, req.(*EntityReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_AddProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddPropertyReq)
+func _ManagementService_AssignRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleAssignmentReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).AddProperty(ctx, in)
+		return srv.(ManagementServiceServer).AssignRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/umg.AuthService/AddProperty",
+		FullMethod: "/umg.ManagementService/AssignRole",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).AddProperty(ctx, req.(*AddPropertyReq))
+		return srv.(ManagementServiceServer).AssignRole(ctx, req.(*RoleAssignmentReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_RemoveProperty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemPropertyReq)
+func _ManagementService_DisallowRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleAssignmentReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).RemoveProperty(ctx, in)
+		return srv.(ManagementServiceServer).DisallowRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/umg.AuthService/RemoveProperty",
+		FullMethod: "/umg.ManagementService/DisallowRole",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).RemoveProperty(ctx, req.(*RemPropertyReq))
+		return srv.(ManagementServiceServer).DisallowRole(ctx, req.(*RoleAssignmentReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _AuthService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "umg.AuthService",
-	HandlerType: (*AuthServiceServer)(nil),
+func _ManagementService_ListRoleUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleUsersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListRoleUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListRoleUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListRoleUsers(ctx, req.(*ListRoleUsersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoliciesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListPolicies(ctx, req.(*ListPoliciesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/AddPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).AddPolicy(ctx, req.(*AddPolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_RemovePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).RemovePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/RemovePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).RemovePolicy(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/GetDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetDomain(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListDomains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListDomains(ctx, req.(*TokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_AddDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).AddDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/AddDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).AddDomain(ctx, req.(*AddDomainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_SetDomainAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDomainAlgorithmReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).SetDomainAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/SetDomainAlgorithm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).SetDomainAlgorithm(ctx, req.(*SetDomainAlgorithmReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListDomainActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListDomainActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/ListDomainActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListDomainActions(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_AddDomainAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDomainActionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).AddDomainAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/AddDomainAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).AddDomainAction(ctx, req.(*AddDomainActionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_RemoveDomainAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).RemoveDomainAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/RemoveDomainAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).RemoveDomainAction(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetAccessExpire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetAccessExpire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/GetAccessExpire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetAccessExpire(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_SetAccessExpire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccessExpireReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).SetAccessExpire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/SetAccessExpire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).SetAccessExpire(ctx, req.(*SetAccessExpireReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_RemoveAccessExpire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).RemoveAccessExpire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/umg.ManagementService/RemoveAccessExpire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).RemoveAccessExpire(ctx, req.(*EntityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ManagementService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "umg.ManagementService",
+	HandlerType: (*ManagementServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "HasDomPerm",
-			Handler:    _AuthService_HasDomPerm_Handler,
+			MethodName: "GetUser",
+			Handler:    _ManagementService_GetUser_Handler,
 		},
 		{
-			MethodName: "HasProdPerm",
-			Handler:    _AuthService_HasProdPerm_Handler,
+			MethodName: "ListUsers",
+			Handler:    _ManagementService_ListUsers_Handler,
 		},
 		{
-			MethodName: "HasPropertyPerm",
-			Handler:    _AuthService_HasPropertyPerm_Handler,
+			MethodName: "AddUser",
+			Handler:    _ManagementService_AddUser_Handler,
 		},
 		{
-			MethodName: "AddProduct",
-			Handler:    _AuthService_AddProduct_Handler,
+			MethodName: "UpdateUser",
+			Handler:    _ManagementService_UpdateUser_Handler,
 		},
 		{
-			MethodName: "RemoveProduct",
-			Handler:    _AuthService_RemoveProduct_Handler,
+			MethodName: "RemoveUser",
+			Handler:    _ManagementService_RemoveUser_Handler,
 		},
 		{
-			MethodName: "AddProperty",
-			Handler:    _AuthService_AddProperty_Handler,
+			MethodName: "GetRole",
+			Handler:    _ManagementService_GetRole_Handler,
 		},
 		{
-			MethodName: "RemoveProperty",
-			Handler:    _AuthService_RemoveProperty_Handler,
+			MethodName: "ListRoles",
+			Handler:    _ManagementService_ListRoles_Handler,
+		},
+		{
+			MethodName: "AddRole",
+			Handler:    _ManagementService_AddRole_Handler,
+		},
+		{
+			MethodName: "UpdateRole",
+			Handler:    _ManagementService_UpdateRole_Handler,
+		},
+		{
+			MethodName: "RemoveRole",
+			Handler:    _ManagementService_RemoveRole_Handler,
+		},
+		{
+			MethodName: "AssignRole",
+			Handler:    _ManagementService_AssignRole_Handler,
+		},
+		{
+			MethodName: "DisallowRole",
+			Handler:    _ManagementService_DisallowRole_Handler,
+		},
+		{
+			MethodName: "ListRoleUsers",
+			Handler:    _ManagementService_ListRoleUsers_Handler,
+		},
+		{
+			MethodName: "ListPolicies",
+			Handler:    _ManagementService_ListPolicies_Handler,
+		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _ManagementService_AddPolicy_Handler,
+		},
+		{
+			MethodName: "RemovePolicy",
+			Handler:    _ManagementService_RemovePolicy_Handler,
+		},
+		{
+			MethodName: "GetDomain",
+			Handler:    _ManagementService_GetDomain_Handler,
+		},
+		{
+			MethodName: "ListDomains",
+			Handler:    _ManagementService_ListDomains_Handler,
+		},
+		{
+			MethodName: "AddDomain",
+			Handler:    _ManagementService_AddDomain_Handler,
+		},
+		{
+			MethodName: "SetDomainAlgorithm",
+			Handler:    _ManagementService_SetDomainAlgorithm_Handler,
+		},
+		{
+			MethodName: "ListDomainActions",
+			Handler:    _ManagementService_ListDomainActions_Handler,
+		},
+		{
+			MethodName: "AddDomainAction",
+			Handler:    _ManagementService_AddDomainAction_Handler,
+		},
+		{
+			MethodName: "RemoveDomainAction",
+			Handler:    _ManagementService_RemoveDomainAction_Handler,
+		},
+		{
+			MethodName: "GetAccessExpire",
+			Handler:    _ManagementService_GetAccessExpire_Handler,
+		},
+		{
+			MethodName: "SetAccessExpire",
+			Handler:    _ManagementService_SetAccessExpire_Handler,
+		},
+		{
+			MethodName: "RemoveAccessExpire",
+			Handler:    _ManagementService_RemoveAccessExpire_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
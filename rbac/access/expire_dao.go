@@ -2,20 +2,18 @@ package access
 
 import (
 	"errors"
-	"log"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/rest_errors"
 	"github.com/boof/umg/settings"
 	"github.com/boof/umg/util/datetime"
 )
 
-func init() {
-	db.Sync(new(Expire))
-}
-
 func (req *ExpireReq) GetDate() (time.Time, error) {
 	return time.Parse(settings.DTLayout, req.Date)
 }
@@ -31,7 +29,12 @@ func (a *Expire) Save() error {
 	a.ID = 0
 
 	_, err = db.Engine.Insert(a)
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(a.UserID)
+	return nil
 }
 
 // Update updates expired time for an user
@@ -42,10 +45,11 @@ func (a *Expire) Update() error {
 	}
 
 	if _, err := db.Engine.Id(access.ID).Cols("expire_at").Update(a); err != nil {
-		log.Print("error while updating expired time: ", err)
+		logger.Log.Error("error while updating expired time", zap.Error(err))
 		return errors.New("database error")
 	}
 
+	db.InvalidatePermissions(a.UserID)
 	return nil
 }
 
@@ -56,7 +60,12 @@ func (a *Expire) RemoveByID() error {
 
 func (a *Expire) RemoveByUserID() error {
 	_, err := db.Engine.Delete(&Expire{UserID: a.UserID})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(a.UserID)
+	return nil
 }
 
 func (a *Expire) validateForInsert() error {
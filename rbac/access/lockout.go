@@ -0,0 +1,25 @@
+package access
+
+import (
+	"time"
+)
+
+const (
+	// LockUser indicates that an account is locked
+	LockUser = "user"
+
+	// LockIP indicates that an IP address is locked
+	LockIP = "ip"
+)
+
+// Lockout used for recording login lockouts
+type Lockout struct {
+	ID          int64     `xorm:"pk not null autoincr 'id'" json:"id"`
+	Scope       string    `xorm:"varchar(8) not null" json:"scope"`
+	Subject     string    `xorm:"varchar(128) not null index" json:"subject"`
+	IP          string    `xorm:"varchar(64) 'ip'" json:"ip"`
+	Failures    int64     `xorm:"not null" json:"failures"`
+	LockedUntil time.Time `xorm:"not null" json:"locked_until"`
+	UnlockedBy  int64     `xorm:"'unlocked_by'" json:"unlocked_by"`
+	CreatedAt   time.Time `xorm:"created" json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package access
+
+import (
+	"errors"
+	"time"
+
+	"github.com/boof/umg/db"
+)
+
+// Save records a new lockout
+func (l *Lockout) Save() error {
+	if l.Scope != LockUser && l.Scope != LockIP {
+		return errors.New("invalid lockout scope")
+	}
+
+	if l.Subject == "" {
+		return errors.New("invalid lockout subject")
+	}
+
+	// remove id
+	l.ID = 0
+
+	_, err := db.Engine.Insert(l)
+	return err
+}
+
+// Unlock marks active lockouts of the current scope and subject as unlocked by the given admin
+func (l *Lockout) Unlock(adminID int64) error {
+	_, err := db.Engine.Where("scope = ? AND subject = ? AND locked_until > ? AND unlocked_by = 0",
+		l.Scope, l.Subject, time.Now()).Cols("unlocked_by").Update(&Lockout{UnlockedBy: adminID})
+	return err
+}
+
+// GetLockouts returns lockouts from newest to oldest, limited to count and
+// offset by page. Only the active lockouts are returned if active is true
+func GetLockouts(count, page int64, active bool) ([]Lockout, int64, error) {
+	var lockouts []Lockout
+
+	session := db.Engine.Desc("id").Limit(int(count), int((page-1)*count))
+	if active {
+		session = session.Where("locked_until > ? AND unlocked_by = 0", time.Now())
+	}
+
+	total, err := session.FindAndCount(&lockouts)
+	return lockouts, total, err
+}
@@ -0,0 +1,10 @@
+package domains
+
+// Action is a valid action of a domain, actions of policies in a domain
+// should match its catalogue when it has one
+type Action struct {
+	ID          int64  `xorm:"pk not null autoincr 'id'" json:"id"`
+	DomainID    int64  `xorm:"not null unique(domain_action) 'domain_id'" json:"domain_id"`
+	Name        string `xorm:"varchar(64) not null unique(domain_action)" json:"name"`
+	Description string `xorm:"varchar(256)" json:"description"`
+}
@@ -0,0 +1,100 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/util/action"
+	"github.com/boof/umg/util/validator"
+)
+
+// Save registers a new action in the catalogue of the domain
+func (a *Action) Save() error {
+	if err := a.validateForInsert(); err != nil {
+		return err
+	}
+
+	// remove id
+	a.ID = 0
+
+	_, err := db.Engine.Insert(a)
+	return err
+}
+
+// GetByID returns an action with the given id
+func (a *Action) GetByID() (*Action, error) {
+	act := &Action{ID: a.ID}
+	if has, err := db.Engine.Get(act); !has || err != nil {
+		return act, errors.New("action not found")
+	}
+
+	return act, nil
+}
+
+// RemoveByID removes the action from the catalogue
+func (a *Action) RemoveByID() error {
+	_, err := db.Engine.Id(a.ID).Delete(&Action{})
+	return err
+}
+
+// validateForInsert validates the action
+func (a *Action) validateForInsert() error {
+	a.Name = strings.ToLower(a.Name)
+
+	if errs := validator.Validate.Var(a.Name, "min=1,max=64"); errs != nil {
+		return errors.New("action name should have a length between 1 and 64")
+	}
+
+	if err := action.Validate(a.Name); err != nil {
+		return err
+	}
+
+	if has, _ := db.Engine.ID(a.DomainID).Get(&Domain{}); a.DomainID < 1 || !has {
+		return errors.New("invalid domain")
+	}
+
+	if has, _ := db.Engine.Get(&Action{DomainID: a.DomainID, Name: a.Name}); has {
+		return errors.New("duplicated action")
+	}
+
+	return nil
+}
+
+// GetActions returns the catalogue of the domain
+func GetActions(domainID int64) ([]Action, error) {
+	var actions []Action
+	err := db.Engine.Where("domain_id = ?", domainID).Asc("name").Find(&actions)
+
+	return actions, err
+}
+
+// ValidateActions checks that each action pattern matches at least one action
+// in the catalogue of the domain, domains without catalogue accept any action
+func ValidateActions(domainID int64, patterns []string) error {
+	catalogue, err := GetActions(domainID)
+	if err != nil {
+		return errors.New("database error")
+	}
+
+	if len(catalogue) == 0 {
+		return nil
+	}
+
+	for _, pattern := range patterns {
+		matched := false
+		for _, act := range catalogue {
+			if action.Match(pattern, act.Name) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("action %s is not in the catalogue of the domain", pattern)
+		}
+	}
+
+	return nil
+}
@@ -5,4 +5,7 @@ package domains
 type Domain struct {
 	ID   int64  `xorm:"pk not null autoincr 'id'" json:"id"`
 	Name string `xorm:"varchar(64) not null unique" json:"name"`
+
+	// combining algorithm of allow and deny policies in the domain
+	Algorithm string `xorm:"varchar(16) not null default 'deny-overrides'" json:"algorithm"`
 }
@@ -8,9 +8,13 @@ import (
 	"github.com/boof/umg/util/validator"
 )
 
-func init() {
-	db.Sync(new(Domain))
-}
+const (
+	// DenyOverrides denies an action if any deny policy applies to it
+	DenyOverrides = "deny-overrides"
+
+	// AllowOverrides allows an action if any allow policy applies to it
+	AllowOverrides = "allow-overrides"
+)
 
 // Save inserts a new domain into the database
 func (d *Domain) Save() error {
@@ -46,16 +50,43 @@ func (d *Domain) GetByName() (*Domain, error) {
 	return domain, nil
 }
 
+// UpdateAlgorithm changes the combining algorithm of the domain
+func (d *Domain) UpdateAlgorithm() error {
+	if !IsValidAlgorithm(d.Algorithm) {
+		return errors.New("invalid combining algorithm")
+	}
+
+	affected, err := db.Engine.ID(d.ID).Cols("algorithm").Update(&Domain{Algorithm: d.Algorithm})
+	if err != nil {
+		return err
+	} else if affected == 0 {
+		return errors.New("domain not found")
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
+}
+
 // RemoveByID removes the domain by id
 func (d *Domain) RemoveByID() error {
 	_, err := db.Engine.Id(d.ID).Delete(&Domain{})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // RemoveByName removes the domain by name
 func (d *Domain) RemoveByName() error {
 	_, err := db.Engine.Delete(&Domain{Name: d.Name})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // validateForInsert validates the domain
@@ -69,6 +100,12 @@ func (d *Domain) validateForInsert() error {
 		return errors.New("domain name should have a length between 1 and 64")
 	}
 
+	if d.Algorithm == "" {
+		d.Algorithm = DenyOverrides
+	} else if !IsValidAlgorithm(d.Algorithm) {
+		return errors.New("invalid combining algorithm")
+	}
+
 	var domains []Domain
 	err := db.Engine.SQL("SELECT * FROM domain WHERE LOWER(name) = ?", strings.ToLower(d.Name)).Find(&domains)
 	if err != nil {
@@ -87,3 +124,18 @@ func GetAllDomains() ([]Domain, error) {
 
 	return domains, err
 }
+
+// IsValidAlgorithm indicates that the given combining algorithm is supported or not
+func IsValidAlgorithm(algorithm string) bool {
+	return algorithm == DenyOverrides || algorithm == AllowOverrides
+}
+
+// Combine decides on an action by the given combining algorithm, allowed and denied
+// indicate that any allow or deny policy applies to the action
+func Combine(algorithm string, allowed, denied bool) bool {
+	if algorithm == AllowOverrides {
+		return allowed
+	}
+
+	return allowed && !denied
+}
@@ -9,4 +9,9 @@ type Policy struct {
 	Properties []int64  `json:"properties"`
 	ProductID  int64    `xorm:"'product_id'" json:"product_id"`
 	DomainID   int64    `xorm:"'domain_id'" json:"domain_id"`
+	Effect     string   `xorm:"varchar(5) not null default 'allow'" json:"effect"`
+
+	// optional expression on the request, like time, ip and attributes,
+	// the policy applies only when it's true
+	Condition string `xorm:"text" json:"condition"`
 }
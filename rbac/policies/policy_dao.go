@@ -2,13 +2,15 @@ package policies
 
 import (
 	"errors"
-	"strings"
+	"fmt"
 
 	"github.com/boof/umg/db"
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/products"
 	"github.com/boof/umg/rbac/properties"
 	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/util/action"
+	"github.com/boof/umg/util/condition"
 	"github.com/boof/umg/util/validator"
 )
 
@@ -21,11 +23,13 @@ const (
 
 	// DomPolicy indicates that the policy is for a domain
 	DomPolicy = "D"
-)
 
-func init() {
-	db.Sync(new(Policy))
-}
+	// Allow is the effect of policies that grant actions
+	Allow = "allow"
+
+	// Deny is the effect of policies that revoke actions granted by other policies
+	Deny = "deny"
+)
 
 // Save inserts a new role to the database
 func (p *Policy) Save() error {
@@ -37,19 +41,48 @@ func (p *Policy) Save() error {
 	p.ID = 0
 
 	_, err := db.Engine.Insert(p)
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
+}
+
+// GetByID returns a policy with the given id
+func (p *Policy) GetByID() (*Policy, error) {
+	if p.ID < 1 {
+		return p, errors.New("invalid id")
+	}
+
+	policy := &Policy{ID: p.ID}
+	if has, err := db.Engine.Get(policy); !has || err != nil {
+		return policy, errors.New("policy not found")
+	}
+
+	return policy, nil
 }
 
 // RemoveByID removes the policy by id
 func (p *Policy) RemoveByID() error {
 	_, err := db.Engine.Id(p.ID).Delete(&Policy{})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // RemoveByRoleID deletes all policies of the current role
 func (p *Policy) RemoveByRoleID() error {
 	_, err := db.Engine.Delete(&Policy{RoleID: p.RoleID})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // IsProductPolicy indicates that the current policy is a product policy or not
@@ -67,22 +100,56 @@ func (p *Policy) IsAllProductPolicy() bool {
 	return p.Type == AllProdPolicy
 }
 
-// LetProdAct indicates that the current policy can let the given action
-// to be carry out on the given product or not
-func (p *Policy) LetProdAct(domId int64, prodID int64, action string) bool {
+// IsDeny indicates that the current policy is a deny policy or not
+func (p *Policy) IsDeny() bool {
+	return p.Effect == Deny
+}
+
+// MatchProdAct indicates that the current policy applies to the given action
+// on the given product in the request context or not, regardless of its effect
+func (p *Policy) MatchProdAct(domId int64, prodID int64, action string, ctx *condition.Context) bool {
 	if p.IsProductPolicy() {
-		return p.ProductID == prodID && p.HasAction(action)
+		return p.ProductID == prodID && p.HasAction(action) && p.matchCondition(ctx)
 	} else if p.IsAllProductPolicy() {
-		return p.DomainID == domId && p.HasAction(action)
+		return p.DomainID == domId && p.HasAction(action) && p.matchCondition(ctx)
 	}
 
 	return false
 }
 
-// LetDomAct indicates that the current policy can let the given action
-// to be carry out on the given domain or not
-func (p *Policy) LetDomAct(domID int64, action string) bool {
-	return p.IsDomainPolicy() && p.DomainID == domID && p.HasAction(action)
+// MatchDomAct indicates that the current policy applies to the given action
+// on the given domain in the request context or not, regardless of its effect
+func (p *Policy) MatchDomAct(domID int64, action string, ctx *condition.Context) bool {
+	return p.IsDomainPolicy() && p.DomainID == domID && p.HasAction(action) && p.matchCondition(ctx)
+}
+
+// OverlapsProdAct indicates that the current policy applies to some actions of
+// the given action pattern on the given product in the request context or not
+func (p *Policy) OverlapsProdAct(domID int64, prodID int64, pattern string, ctx *condition.Context) bool {
+	if p.IsProductPolicy() {
+		return p.ProductID == prodID && p.overlapsAction(pattern) && p.matchCondition(ctx)
+	} else if p.IsAllProductPolicy() {
+		return p.DomainID == domID && p.overlapsAction(pattern) && p.matchCondition(ctx)
+	}
+
+	return false
+}
+
+// OverlapsDomAct indicates that the current policy applies to some actions of
+// the given action pattern on the given domain in the request context or not
+func (p *Policy) OverlapsDomAct(domID int64, pattern string, ctx *condition.Context) bool {
+	return p.IsDomainPolicy() && p.DomainID == domID && p.overlapsAction(pattern) && p.matchCondition(ctx)
+}
+
+// matchCondition evaluates the condition of the policy, if the request doesn't
+// have a variable of the condition deny policies apply and allow policies don't
+func (p *Policy) matchCondition(ctx *condition.Context) bool {
+	ok, err := condition.Evaluate(p.Condition, ctx)
+	if err != nil {
+		return p.IsDeny()
+	}
+
+	return ok
 }
 
 // ValidateForInsert validates the policy
@@ -91,6 +158,12 @@ func (p *Policy) ValidateForInsert() error {
 		return errors.New("invalid policy type")
 	}
 
+	if p.Effect == "" {
+		p.Effect = Allow
+	} else if p.Effect != Allow && p.Effect != Deny {
+		return errors.New("invalid policy effect")
+	}
+
 	if len(p.Actions) == 0 {
 		return errors.New("actions can't be empty")
 	}
@@ -98,8 +171,10 @@ func (p *Policy) ValidateForInsert() error {
 	for _, act := range p.Actions {
 		if act == "" {
 			return errors.New("invalid action")
-		} else if act == "*" && len(p.Actions) > 1 {
+		} else if act == action.Wildcard && len(p.Actions) > 1 {
 			return errors.New("you can't combine * action with other ones")
+		} else if err := action.ValidatePattern(act); err != nil {
+			return err
 		}
 	}
 
@@ -108,6 +183,12 @@ func (p *Policy) ValidateForInsert() error {
 		return errors.New("actions text length should be between 1 and 32")
 	}
 
+	if len(p.Condition) > 1024 {
+		return errors.New("condition should be shorter than 1024 characters")
+	} else if err := condition.Validate(p.Condition); p.Condition != "" && err != nil {
+		return fmt.Errorf("invalid condition: %v", err)
+	}
+
 	role := &roles.Role{ID: p.RoleID}
 	if has, _ := db.Engine.Get(role); p.RoleID < 1 || !has {
 		return errors.New("invalid role")
@@ -121,6 +202,10 @@ func (p *Policy) ValidateForInsert() error {
 		return errors.New("invalid domain")
 	}
 
+	if err := domains.ValidateActions(p.DomainID, p.Actions); err != nil {
+		return err
+	}
+
 	if p.IsProductPolicy() {
 		if has, _ := db.Engine.Get(&products.Product{ID: p.ProductID}); p.ProductID < 1 || !has {
 			return errors.New("invalid product")
@@ -136,10 +221,23 @@ func (p *Policy) ValidateForInsert() error {
 	return nil
 }
 
-// HasAction indicates that policy has given action or not
-func (p *Policy) HasAction(action string) bool {
+// HasAction indicates that any action pattern of the policy matches
+// the given action or not
+func (p *Policy) HasAction(act string) bool {
+	for _, pattern := range p.Actions {
+		if action.Match(pattern, act) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overlapsAction indicates that any action pattern of the policy may match
+// an action of the given pattern or not
+func (p *Policy) overlapsAction(pattern string) bool {
 	for _, act := range p.Actions {
-		if act == "*" || strings.ToLower(act) == strings.ToLower(action) {
+		if action.Overlap(act, pattern) {
 			return true
 		}
 	}
@@ -147,6 +245,11 @@ func (p *Policy) HasAction(action string) bool {
 	return false
 }
 
+// HasAllActions indicates that the policy applies to every action or not
+func (p *Policy) HasAllActions() bool {
+	return len(p.Actions) == 1 && p.Actions[0] == action.Wildcard
+}
+
 // GetPolicies returns all policies for the current role
 func (p *Policy) GetRolePolicies() ([]Policy, error) {
 	var policies []Policy
@@ -203,7 +306,10 @@ func (p *Policy) GetNamedPolicies() ([]map[string]interface{}, error) {
 		}
 
 		p["id"] = policy.ID
+		p["role_id"] = policy.RoleID
 		p["type"] = policy.Type
+		p["effect"] = policy.Effect
+		p["condition"] = policy.Condition
 
 		allProperties := make([]*properties.Property, 0)
 		for _, pID := range policy.Properties {
@@ -8,10 +8,6 @@ import (
 	"github.com/boof/umg/util/validator"
 )
 
-func init() {
-	db.Sync(new(Product))
-}
-
 // Save inserts a new product into the database
 func (p *Product) Save() error {
 	err := p.validateForInsert()
@@ -23,7 +19,12 @@ func (p *Product) Save() error {
 	p.ID = 0
 
 	_, err = db.Engine.Insert(p)
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // GetByID returns a Product with the given id
@@ -49,13 +50,23 @@ func (p *Product) GetByName() (*Product, error) {
 // RemoveByID removes the product by id
 func (p *Product) RemoveByID() error {
 	_, err := db.Engine.Id(p.ID).Delete(&Product{})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // RemoveByName removes the product by name
 func (p *Product) RemoveByName() error {
 	_, err := db.Engine.Delete(&Product{Name: p.Name})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // validateForInsert validates
@@ -8,10 +8,6 @@ import (
 	"github.com/boof/umg/util/validator"
 )
 
-func init() {
-	db.Sync(new(Property))
-}
-
 func (p *Property) Save() error {
 	if _, err := p.ValidateForInsert(); err != nil {
 		return err
@@ -79,7 +75,12 @@ func (p *Property) HasValidType() bool {
 // RemoveByID removes the property by id
 func (p *Property) RemoveByID() error {
 	_, err := db.Engine.Id(p.ID).Delete(&Property{})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // GetProperties returns all domains
@@ -0,0 +1,110 @@
+package roles
+
+import (
+	"errors"
+
+	"github.com/boof/umg/db"
+)
+
+// ResolveIDs returns the given roles and all of their ancestors, each role
+// inherits the policies of its parents
+func ResolveIDs(roleIDs []int64) []int64 {
+	res := make([]int64, 0, len(roleIDs))
+	seen := make(map[int64]bool)
+
+	queue := append([]int64{}, roleIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, id)
+
+		role, err := (&Role{ID: id}).GetByID()
+		if err != nil {
+			// removed roles don't have any policy
+			continue
+		}
+
+		queue = append(queue, role.ParentIDs...)
+	}
+
+	return res
+}
+
+// validateParents checks that parents exist and inheriting them doesn't make a cycle
+func (r *Role) validateParents() error {
+	seen := make(map[int64]bool)
+
+	for _, parentID := range r.ParentIDs {
+		if parentID == r.ID {
+			return errors.New("role can't inherit itself")
+		}
+
+		if seen[parentID] {
+			return errors.New("duplicated parent role")
+		}
+		seen[parentID] = true
+
+		parent, err := (&Role{ID: parentID}).GetByID()
+		if err != nil {
+			return errors.New("invalid parent role")
+		}
+
+		if parent.IsAdmin() {
+			return errors.New("roles can't inherit admin role")
+		}
+	}
+
+	// new roles can't be an ancestor of other roles
+	if r.ID < 1 {
+		return nil
+	}
+
+	for _, id := range ResolveIDs(r.ParentIDs) {
+		if id == r.ID {
+			return errors.New("role inheritance can't have a cycle")
+		}
+	}
+
+	return nil
+}
+
+// parentIDs returns parents of the role, never nil
+func (r *Role) parentIDs() []int64 {
+	if r.ParentIDs == nil {
+		return make([]int64, 0)
+	}
+
+	return r.ParentIDs
+}
+
+// RemoveParent removes the role from parents of other roles
+func RemoveParent(roleID int64) error {
+	var all []Role
+	if err := db.Engine.Find(&all); err != nil {
+		return err
+	}
+
+	for _, role := range all {
+		parentIDs := make([]int64, 0)
+		for _, id := range role.ParentIDs {
+			if id != roleID {
+				parentIDs = append(parentIDs, id)
+			}
+		}
+
+		if len(parentIDs) == len(role.ParentIDs) {
+			continue
+		}
+
+		if _, err := db.Engine.ID(role.ID).Cols("parent_ids").Update(&Role{ParentIDs: parentIDs}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
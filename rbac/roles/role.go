@@ -16,5 +16,6 @@ const (
 type Role struct {
 	ID        int64     `xorm:"pk not null autoincr 'id'" json:"id"`
 	Name      string    `xorm:"varchar(64) not null unique" json:"name"`
+	ParentIDs []int64   `xorm:"'parent_ids'" json:"parent_ids"`
 	CreatedAt time.Time `xorm:"created" json:"-"`
 }
@@ -3,23 +3,22 @@ package roles
 import (
 	"encoding/json"
 	"errors"
+	"strings"
+
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/util/query"
 	"github.com/boof/umg/util/validator"
-	"strings"
 )
 
 const (
 	adminRole = "admin"
 )
 
-func init() {
-	db.Sync(new(Role))
-}
-
 func (r *Role) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&map[string]interface{}{
 		"id":         r.ID,
 		"name":       r.Name,
+		"parent_ids": r.parentIDs(),
 		"created_at": r.CreatedAt.Format("Jan 02, 2006"),
 	})
 }
@@ -43,7 +42,12 @@ func (r *Role) Update() error {
 	}
 
 	_, err := db.Engine.Id(r.ID).AllCols().Update(r)
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidateAllPermissions()
+	return nil
 }
 
 // GetByID returns a role with the given id
@@ -99,7 +103,7 @@ func (r *Role) ValidateForInsert() error {
 		return errors.New("duplicated role name")
 	}
 
-	return nil
+	return r.validateParents()
 }
 
 func (r *Role) validateFroUpdate() error {
@@ -119,25 +123,38 @@ func (r *Role) validateFroUpdate() error {
 		}
 	}
 
-	return nil
+	// parents are kept when they aren't sent, an empty list clears them
+	if r.ParentIDs == nil {
+		r.ParentIDs = old.ParentIDs
+	}
+
+	return r.validateParents()
 }
 
 func (r *Role) IsAdmin() bool {
 	return strings.ToLower(r.Name) == adminRole
 }
 
-// GetAllRoles returns all roles
+// sortColumns maps the sort fields to their ORDER BY expressions
+var sortColumns = map[string]string{
+	ID:   "id",
+	Name: "name",
+}
+
+// GetAllRoles returns all roles sorted by given field, limited to count
+// and offset by page
 func GetAllRoles(count, page int, order, sortBy string) ([]Role, error) {
-	var roles []Role
+	if count < 1 || page < 1 {
+		return nil, errors.New("invalid pagination")
+	}
 
-	session := db.Engine.Limit(count, (page-1)*count)
-	if order == Desc {
-		session = session.Desc(sortBy)
-	} else {
-		session = session.Asc(sortBy)
+	orderBy, err := query.OrderBy(sortBy, order, sortColumns)
+	if err != nil {
+		return nil, err
 	}
 
-	err := session.Find(&roles)
+	var roles []Role
+	err = db.Engine.OrderBy(orderBy).Limit(count, (page-1)*count).Find(&roles)
 	return roles, err
 }
 
@@ -180,7 +197,7 @@ func IsValidSort(sortBy ...string) bool {
 	}
 
 	for _, by := range sortBy {
-		if by != Name && by != ID {
+		if _, ok := sortColumns[by]; !ok {
 			return false
 		}
 	}
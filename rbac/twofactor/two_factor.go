@@ -0,0 +1,19 @@
+package twofactor
+
+import "time"
+
+// TwoFactor used for saving TOTP two-factor authentication of users
+type TwoFactor struct {
+	ID      int64  `xorm:"pk not null autoincr 'id'"`
+	UserID  int64  `xorm:"not null unique 'user_id'"`
+	Secret  string `xorm:"not null"`
+	Enabled bool   `xorm:"not null"`
+
+	// last accepted time step, used for rejecting replayed codes
+	LastStep int64 `xorm:"'last_step'"`
+
+	// sha256 hashes of unused recovery codes
+	RecoveryCodes []string  `xorm:"'recovery_codes'"`
+	CreatedAt     time.Time `xorm:"created"`
+	UpdatedAt     time.Time `xorm:"updated"`
+}
@@ -0,0 +1,159 @@
+package twofactor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/util/totp"
+)
+
+const (
+	recoveryCodesCount = 10
+)
+
+// Enroll creates a new disabled secret for the user, an existing
+// disabled enrollment is replaced
+func (t *TwoFactor) Enroll() error {
+	if old, err := t.GetByUserID(); err == nil {
+		if old.Enabled {
+			return errors.New("two-factor authentication is already enabled")
+		}
+
+		if err := old.RemoveByUserID(); err != nil {
+			return err
+		}
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return errors.New("unable to generate secret")
+	}
+
+	// remove id
+	t.ID = 0
+	t.Secret = secret
+	t.Enabled = false
+	t.LastStep = 0
+	t.RecoveryCodes = nil
+
+	_, err = db.Engine.Insert(t)
+	return err
+}
+
+// Enable enables two-factor authentication and returns new recovery codes,
+// recovery codes are only returned here and just their hashes are saved
+func (t *TwoFactor) Enable() ([]string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, errors.New("unable to generate recovery codes")
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	t.Enabled = true
+	t.RecoveryCodes = hashes
+
+	_, err := db.Engine.ID(t.ID).Cols("enabled", "recovery_codes", "last_step").Update(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Verify checks a TOTP code, each code can be used only once. The step is
+// saved only if it is newer than the saved one, so concurrent requests with
+// the same code can't both pass
+func (t *TwoFactor) Verify(code string) bool {
+	step, ok := totp.Validate(t.Secret, code, time.Now())
+	if !ok || step <= t.LastStep {
+		return false
+	}
+
+	affected, err := db.Engine.ID(t.ID).Where("last_step < ?", step).Cols("last_step").Update(&TwoFactor{LastStep: step})
+	if err != nil || affected == 0 {
+		return false
+	}
+
+	t.LastStep = step
+	return true
+}
+
+// UseRecoveryCode checks and consumes a recovery code. The code is removed
+// only while it's still in the saved list, so concurrent requests with the
+// same code can't both pass
+func (t *TwoFactor) UseRecoveryCode(code string) bool {
+	hash := hashRecoveryCode(code)
+
+	res, err := db.Engine.Exec("UPDATE two_factor SET recovery_codes = (recovery_codes::jsonb - ?::text)::text, "+
+		"updated_at = ? WHERE id = ? AND jsonb_exists(recovery_codes::jsonb, ?)", hash, time.Now(), t.ID, hash)
+	if err != nil {
+		return false
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return false
+	}
+
+	remaining := make([]string, 0, len(t.RecoveryCodes))
+	for _, h := range t.RecoveryCodes {
+		if h != hash {
+			remaining = append(remaining, h)
+		}
+	}
+	t.RecoveryCodes = remaining
+
+	return true
+}
+
+// GetByUserID returns two-factor authentication of the given user
+func (t *TwoFactor) GetByUserID() (*TwoFactor, error) {
+	tf := &TwoFactor{UserID: t.UserID}
+	if has, err := db.Engine.Get(tf); !has || err != nil {
+		return nil, errors.New("two-factor authentication not found")
+	}
+
+	return tf, nil
+}
+
+// RemoveByUserID removes two-factor authentication of the current user
+func (t *TwoFactor) RemoveByUserID() error {
+	_, err := db.Engine.Delete(&TwoFactor{UserID: t.UserID})
+	return err
+}
+
+// IsEnabled indicates that the given user has enabled two-factor authentication or not
+func IsEnabled(userID int64) bool {
+	tf, err := (&TwoFactor{UserID: userID}).GetByUserID()
+	return err == nil && tf.Enabled
+}
+
+// newRecoveryCode generates a random code like `a1b2c-3d4e5`
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s", code[:5], code[5:]), nil
+}
+
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,11 @@
+package users
+
+import "time"
+
+// PasswordHistory used for saving hashes of the recent passwords of users
+type PasswordHistory struct {
+	ID        int64     `xorm:"pk not null autoincr 'id'"`
+	UserID    int64     `xorm:"not null index 'user_id'"`
+	Hash      string    `xorm:"not null"`
+	CreatedAt time.Time `xorm:"created"`
+}
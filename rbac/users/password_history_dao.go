@@ -0,0 +1,56 @@
+package users
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/util/password"
+)
+
+// UsedRecently indicates that the given password is one of the recent passwords of the user
+func (u *User) UsedRecently(pass string) bool {
+	limit := settings.Conf.Password.History
+	if limit < 1 {
+		return false
+	}
+
+	var history []PasswordHistory
+	err := db.Engine.Where("user_id = ?", u.ID).Desc("id").Limit(limit).Find(&history)
+	if err != nil {
+		logger.Log.Error("error while reading password history", zap.Error(err))
+		return false
+	}
+
+	for _, h := range history {
+		if password.IsValidPass(pass, h.Hash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// savePasswordHistory saves the current password hash and removes the older ones
+func (u *User) savePasswordHistory() {
+	limit := settings.Conf.Password.History
+	if limit < 1 {
+		return
+	}
+
+	if _, err := db.Engine.Insert(&PasswordHistory{UserID: u.ID, Hash: u.Password}); err != nil {
+		logger.Log.Error("error while saving password history", zap.Error(err))
+		return
+	}
+
+	var keep []PasswordHistory
+	if err := db.Engine.Where("user_id = ?", u.ID).Desc("id").Limit(limit).Find(&keep); err != nil || len(keep) < limit {
+		return
+	}
+
+	_, err := db.Engine.Where("user_id = ? AND id < ?", u.ID, keep[len(keep)-1].ID).Delete(&PasswordHistory{})
+	if err != nil {
+		logger.Log.Error("error while removing old password history", zap.Error(err))
+	}
+}
@@ -0,0 +1,25 @@
+package users
+
+import "time"
+
+const (
+	// access expiry states of the search filter
+	ExpireActive  = "active"
+	ExpireExpired = "expired"
+	ExpireNone    = "none"
+)
+
+// SearchFilter used for searching users, zero fields don't filter
+type SearchFilter struct {
+	Text   string
+	RoleID int64
+
+	// nil for both online and offline users
+	Online *bool
+
+	// one of ExpireActive, ExpireExpired and ExpireNone
+	Expire string
+
+	LastLoginFrom time.Time
+	LastLoginTo   time.Time
+}
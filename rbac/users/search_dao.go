@@ -0,0 +1,144 @@
+package users
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/util/datetime"
+	"github.com/boof/umg/util/query"
+)
+
+// searchDocument is the searched text of a user, indexes of migration
+// 0003_user_search are built on the same expression
+const searchDocument = `(coalesce(username, '') || ' ' || coalesce(name, '') || ' ' || coalesce(company, '') || ' ' || ` +
+	`coalesce(email, '') || ' ' || coalesce(phone1, '') || ' ' || coalesce(phone2, '') || ' ' || ` +
+	`coalesce(address1, '') || ' ' || coalesce(address2, ''))`
+
+// Search returns users that match the filter, ranked by full-text and trigram
+// similarity of the text, limited to count and offset by page, with number
+// of all matched users
+func Search(filter SearchFilter, count, page int64) ([]User, int64, error) {
+	if count < 1 || page < 1 {
+		return nil, 0, errors.New("invalid pagination")
+	}
+
+	where, args, err := filter.conditions()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if _, err := db.Engine.SQL("SELECT count(*) FROM \"user\" WHERE "+where, args...).Get(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 {
+		return make([]User, 0), 0, nil
+	}
+
+	orderBy := "id"
+	if filter.Text != "" {
+		orderBy = "ts_rank(to_tsvector('simple', " + searchDocument + "), plainto_tsquery('simple', ?)) + " +
+			"word_similarity(?, " + searchDocument + ") DESC, id"
+		args = append(args, filter.Text, filter.Text)
+	}
+
+	var ids []int64
+	args = append(args, count, (page-1)*count)
+	err = db.Engine.SQL("SELECT id FROM \"user\" WHERE "+where+" ORDER BY "+orderBy+" LIMIT ? OFFSET ?", args...).Find(&ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, err := getByIDs(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, LoadRoles(users)
+}
+
+// conditions returns the WHERE clause of the filter with its arguments
+func (f SearchFilter) conditions() (string, []interface{}, error) {
+	conds := []string{"TRUE"}
+	args := make([]interface{}, 0)
+
+	if f.Text != "" {
+		// full-text match of words, fuzzy match of misspelled words or substring match
+		conds = append(conds, "(to_tsvector('simple', "+searchDocument+") @@ plainto_tsquery('simple', ?) OR "+
+			"? <% "+searchDocument+" OR "+searchDocument+" ILIKE ?)")
+		args = append(args, f.Text, f.Text, query.Contains(f.Text))
+	}
+
+	if f.RoleID > 0 {
+		conds = append(conds, "EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = \"user\".id AND ur.role_id = ?)")
+		args = append(args, f.RoleID)
+	}
+
+	if f.Online != nil {
+		online, err := db.GetOnline()
+		if err != nil {
+			return "", nil, err
+		}
+
+		// a single array parameter, there can be too many online users for a parameter each
+		if *f.Online {
+			conds = append(conds, "id = ANY(?)")
+		} else {
+			conds = append(conds, "NOT id = ANY(?)")
+		}
+		args = append(args, pq.Int64Array(online))
+	}
+
+	// expiry dates are saved in eastern canada time, see access.Expired
+	switch f.Expire {
+	case "":
+	case ExpireActive:
+		conds = append(conds, "EXISTS (SELECT 1 FROM expire e WHERE e.user_id = \"user\".id AND e.expire_at >= ?)")
+		args = append(args, datetime.NowInEasternCanada())
+	case ExpireExpired:
+		conds = append(conds, "EXISTS (SELECT 1 FROM expire e WHERE e.user_id = \"user\".id AND e.expire_at < ?)")
+		args = append(args, datetime.NowInEasternCanada())
+	case ExpireNone:
+		conds = append(conds, "NOT EXISTS (SELECT 1 FROM expire e WHERE e.user_id = \"user\".id)")
+	default:
+		return "", nil, errors.New("invalid access expiry state")
+	}
+
+	if !f.LastLoginFrom.IsZero() {
+		conds = append(conds, "last_login >= ?")
+		args = append(args, f.LastLoginFrom)
+	}
+
+	if !f.LastLoginTo.IsZero() {
+		conds = append(conds, "last_login <= ?")
+		args = append(args, f.LastLoginTo)
+	}
+
+	return strings.Join(conds, " AND "), args, nil
+}
+
+// getByIDs returns the users in order of the given ids
+func getByIDs(ids []int64) ([]User, error) {
+	var found []User
+	if err := db.Engine.In("id", ids).Find(&found); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]User, len(found))
+	for _, u := range found {
+		byID[u.ID] = u
+	}
+
+	users := make([]User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			users = append(users, u)
+		}
+	}
+
+	return users, nil
+}
@@ -35,7 +35,7 @@ type User struct {
 	Phone2    string    `json:"phone2"`
 	Fax1      string    `json:"fax1"`
 	Fax2      string    `json:"fax2"`
-	RoleIDs   []int64   `xorm:"'role_ids'" json:"role_ids"`
+	RoleIDs   []int64   `xorm:"-" json:"role_ids"` // loaded from user_roles table
 	LastLogin time.Time `xorm:"last_login" json:"last_login"`
 	CreatedAt time.Time `xorm:"created" json:"-"`
 	UpdatedAt time.Time `xorm:"updated" json:"-"`
@@ -2,19 +2,18 @@ package users
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/util/password"
+	"github.com/boof/umg/util/query"
 	"github.com/boof/umg/util/validator"
 )
 
-func init() {
-	db.Sync(new(User))
-}
-
 // Save inserts a new user into the database
 func (u *User) Save() error {
 	err := u.validateForInsert()
@@ -37,7 +36,12 @@ func (u *User) Save() error {
 	u.Password = hash
 
 	_, err = db.Engine.Insert(u)
-	return err
+	if err != nil {
+		return err
+	}
+
+	u.savePasswordHistory()
+	return nil
 }
 
 func (u *User) Update() error {
@@ -46,7 +50,12 @@ func (u *User) Update() error {
 	}
 
 	_, err := db.Engine.Id(u.ID).AllCols().Update(u)
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(u.ID)
+	return nil
 }
 
 func (u *User) ChangePassword(newPass string) error {
@@ -63,6 +72,26 @@ func (u *User) ChangePassword(newPass string) error {
 
 	u.Password = hash
 
+	_, err = db.Engine.Id(u.ID).Cols("password").Update(u)
+	if err != nil {
+		return err
+	}
+
+	u.savePasswordHistory()
+	u.revokeTokens()
+	return nil
+}
+
+// RehashPassword replaces the password hash with a hash of the current
+// algorithm, it doesn't change the password itself
+func (u *User) RehashPassword(pass string) error {
+	hash, err := password.HashPassword(pass)
+	if err != nil {
+		return errors.New("unable to hash password")
+	}
+
+	u.Password = hash
+
 	_, err = db.Engine.Id(u.ID).Cols("password").Update(u)
 	return err
 }
@@ -89,8 +118,35 @@ func (u *User) SaveWithRoles() error {
 
 	u.Password = hash
 
-	_, err = db.Engine.Insert(u)
-	return err
+	session := db.Engine.NewSession()
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := session.Insert(u); err != nil {
+		return err
+	}
+
+	seen := make(map[int64]bool)
+	for _, roleID := range u.RoleIDs {
+		if seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		if _, err := session.Insert(&UserRole{UserID: u.ID, RoleID: roleID}); err != nil {
+			return err
+		}
+	}
+
+	if err := session.Commit(); err != nil {
+		return err
+	}
+
+	u.savePasswordHistory()
+	return u.loadRoles()
 }
 
 // GetByID returns a User with the given id
@@ -100,7 +156,7 @@ func (u *User) GetByID() (*User, error) {
 		return user, errors.New("user not found")
 	}
 
-	return user, nil
+	return user, user.loadRoles()
 }
 
 // GetByEmail returns a User with the given email
@@ -110,7 +166,7 @@ func (u *User) GetByEmail() (*User, error) {
 		return user, errors.New("user not found")
 	}
 
-	return user, nil
+	return user, user.loadRoles()
 }
 
 func (u *User) GetByUsername() (*User, error) {
@@ -119,13 +175,19 @@ func (u *User) GetByUsername() (*User, error) {
 		return user, errors.New("user not found")
 	}
 
-	return user, nil
+	return user, user.loadRoles()
 }
 
 // RemoveByID removes the user by id
 func (u *User) RemoveByID() error {
 	_, err := db.Engine.Id(u.ID).Delete(&User{})
-	return err
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(u.ID)
+	u.revokeTokens()
+	return nil
 }
 
 // RemoveByUserName removes the user by username
@@ -193,8 +255,10 @@ func (u *User) validateFroUpdate() error {
 		}
 	}
 
+	// these are changed by their own calls, all columns are updated
 	u.RoleIDs = old.RoleIDs
 	u.Password = old.Password
+	u.LastLogin = old.LastLogin
 
 	return nil
 }
@@ -265,90 +329,54 @@ func (u *User) HasUniqueEmail() (bool, error) {
 	return true, nil
 }
 
-// AssignRole assigns a role to the current user
-func (u *User) AssignRole(roleID int64) error {
-	// validate role
-	if has, _ := db.Engine.Get(&roles.Role{ID: roleID}); !has {
-		return errors.New("invalid role")
-	}
-
-	if u.RoleIDs == nil {
-		u.RoleIDs = make([]int64, 0)
-	}
-
-	for _, id := range u.RoleIDs {
-		if id == roleID {
-			return nil
-		}
-	}
-	u.RoleIDs = append(u.RoleIDs, roleID)
-
-	// Todo: validate other roles inside a lock
-	// Todo: fetch users role from database
-
-	// update roles
-	_, err := db.Engine.ID(u.ID).Update(&User{RoleIDs: u.RoleIDs})
-	return err
-}
-
-func (u *User) DisallowRole(roleID int64) error {
-	// validate role
-	if has, _ := db.Engine.Get(&roles.Role{ID: roleID}); !has {
-		return errors.New("invalid role")
-	}
-
-	roleIDs := make([]int64, 0)
-	for _, id := range u.RoleIDs {
-		if id != roleID {
-			roleIDs = append(roleIDs, id)
-		}
+// revokeTokens revokes all issued tokens of the current user
+func (u *User) revokeTokens() {
+	if err := db.RevokeUserTokens(u.ID); err != nil {
+		logger.Log.Error("unable to revoke tokens", zap.Int64("user_id", u.ID), zap.Error(err))
 	}
-
-	// Todo: validate other roles inside a lock
-	// Todo: fetch users role from database
-
-	// update roles
-	_, err := db.Engine.ID(u.ID).Cols("role_ids").Update(&User{RoleIDs: roleIDs})
-	return err
 }
 
-// IsAdmin indicates that current user has admin permission or not
-func (u *User) IsAdmin() bool {
-	for _, roleID := range u.RoleIDs {
-		r, err := (&roles.Role{ID: roleID}).GetByID()
-		if err == nil && r.Name == "admin" {
-			return true
-		}
-	}
-
-	return false
+// sortColumns maps the sort fields to their ORDER BY expressions
+var sortColumns = map[string]string{
+	ID:        "id",
+	Name:      "name",
+	Username:  "LOWER(username)",
+	Email:     "LOWER(email)",
+	LastLogin: "last_login",
+	Created:   "created_at",
 }
 
 // GetAll returns all users sorted by given field, limited to count
 // and offset by page
 func GetAll(count, page int64, order string, sortBy string) ([]User, error) {
-	var users []User
-	var query string
+	if count < 1 || page < 1 {
+		return nil, errors.New("invalid pagination")
+	}
+
+	session := db.Engine.Limit(int(count), int((page-1)*count))
 
-	if sortBy == LastLogin {
+	switch sortBy {
+	case LastLogin:
 		order = reverseOrder(order)
-		query = "SELECT * FROM \"user\" WHERE last_login IS NOT NULL ORDER BY %s %s LIMIT %d OFFSET %d;"
-	} else if sortBy == Created {
+		session = session.Where("last_login IS NOT NULL")
+	case Created:
 		order = reverseOrder(order)
-		query = "SELECT * FROM \"user\" WHERE created_at IS NOT NULL ORDER BY %s %s LIMIT %d OFFSET %d;"
-	} else if sortBy == ID {
-		query = "SELECT * FROM \"user\" ORDER BY %s %s LIMIT %d OFFSET %d;"
-	} else if sortBy == Name {
-		query = "SELECT * FROM \"user\" WHERE NOT name = '' AND name IS NOT NULL ORDER BY %s %s LIMIT %d OFFSET %d;"
-	} else {
-		query = "SELECT * FROM \"user\" ORDER BY LOWER(%s) %s LIMIT %d OFFSET %d;"
+		session = session.Where("created_at IS NOT NULL")
+	case Name:
+		session = session.Where("name <> '' AND name IS NOT NULL")
 	}
 
-	// populate query
-	query = fmt.Sprintf(query, sortBy, order, count, (page-1)*count)
+	orderBy, err := query.OrderBy(sortBy, order, sortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := session.OrderBy(orderBy).Find(&users); err != nil {
+		return nil, err
+	}
 
-	err := db.Engine.SQL(query).Find(&users)
-	return users, err
+	return users, LoadRoles(users)
 }
 
 func Count() (int64, error) {
@@ -361,7 +389,7 @@ func IsValidSort(sortBy ...string) bool {
 	}
 
 	for _, by := range sortBy {
-		if by != Name && by != Username && by != Email && by != LastLogin && by != ID && by != Created {
+		if _, ok := sortColumns[by]; !ok {
 			return false
 		}
 	}
@@ -0,0 +1,14 @@
+package users
+
+import "time"
+
+// UserRole assigns a role to a user
+type UserRole struct {
+	UserID    int64     `xorm:"pk 'user_id'"`
+	RoleID    int64     `xorm:"pk index 'role_id'"`
+	CreatedAt time.Time `xorm:"created"`
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
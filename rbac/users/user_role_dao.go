@@ -0,0 +1,152 @@
+package users
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/rbac/roles"
+)
+
+const adminRole = "admin"
+
+// GetRoleIDs returns ids of the roles assigned to the current user
+func (u *User) GetRoleIDs() ([]int64, error) {
+	var assigned []UserRole
+	err := db.Engine.Where("user_id = ?", u.ID).Asc("created_at", "role_id").Find(&assigned)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]int64, 0, len(assigned))
+	for _, ur := range assigned {
+		roleIDs = append(roleIDs, ur.RoleID)
+	}
+
+	return roleIDs, nil
+}
+
+// loadRoles sets roles of the current user from the database
+func (u *User) loadRoles() error {
+	roleIDs, err := u.GetRoleIDs()
+	if err != nil {
+		return err
+	}
+
+	u.RoleIDs = roleIDs
+	return nil
+}
+
+// LoadRoles sets roles of the given users with a single query
+func LoadRoles(all []User) error {
+	if len(all) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(all))
+	for _, u := range all {
+		ids = append(ids, u.ID)
+	}
+
+	var assigned []UserRole
+	err := db.Engine.In("user_id", ids).Asc("created_at", "role_id").Find(&assigned)
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[int64][]int64)
+	for _, ur := range assigned {
+		byUser[ur.UserID] = append(byUser[ur.UserID], ur.RoleID)
+	}
+
+	for i := range all {
+		all[i].RoleIDs = byUser[all[i].ID]
+		if all[i].RoleIDs == nil {
+			all[i].RoleIDs = make([]int64, 0)
+		}
+	}
+
+	return nil
+}
+
+// AssignRole assigns a role to the current user
+func (u *User) AssignRole(roleID int64) error {
+	// validate role
+	if has, _ := db.Engine.Get(&roles.Role{ID: roleID}); !has {
+		return errors.New("invalid role")
+	}
+
+	_, err := db.Engine.Exec("INSERT INTO user_roles (user_id, role_id, created_at) VALUES (?, ?, ?) "+
+		"ON CONFLICT DO NOTHING", u.ID, roleID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(u.ID)
+	return u.loadRoles()
+}
+
+// DisallowRole removes a role from roles of the current user
+func (u *User) DisallowRole(roleID int64) error {
+	// validate role
+	if has, _ := db.Engine.Get(&roles.Role{ID: roleID}); !has {
+		return errors.New("invalid role")
+	}
+
+	_, err := db.Engine.Delete(&UserRole{UserID: u.ID, RoleID: roleID})
+	if err != nil {
+		return err
+	}
+
+	db.InvalidatePermissions(u.ID)
+	u.revokeTokens()
+	return u.loadRoles()
+}
+
+// IsAdmin indicates that current user has admin permission or not
+func (u *User) IsAdmin() bool {
+	has, err := db.Engine.SQL("SELECT 1 FROM user_roles ur JOIN role r ON r.id = ur.role_id "+
+		"WHERE ur.user_id = ? AND LOWER(r.name) = ?", u.ID, adminRole).Exist()
+	if err != nil {
+		logger.Log.Error("unable to check admin role", zap.Int64("user_id", u.ID), zap.Error(err))
+		return false
+	}
+
+	return has
+}
+
+// GetByRole returns users of the role sorted by id, limited to count
+// and offset by page
+func GetByRole(roleID, count, page int64) ([]User, error) {
+	var users []User
+	err := db.Engine.SQL("SELECT u.* FROM \"user\" u JOIN user_roles ur ON ur.user_id = u.id "+
+		"WHERE ur.role_id = ? ORDER BY u.id LIMIT ? OFFSET ?", roleID, count, (page-1)*count).Find(&users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, LoadRoles(users)
+}
+
+// GetIDsByRole returns ids of all users of the role
+func GetIDsByRole(roleID int64) ([]int64, error) {
+	var assigned []UserRole
+	if err := db.Engine.Where("role_id = ?", roleID).Find(&assigned); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(assigned))
+	for _, ur := range assigned {
+		ids = append(ids, ur.UserID)
+	}
+
+	return ids, nil
+}
+
+// CountByRole returns number of the users of the role
+func CountByRole(roleID int64) (int64, error) {
+	return db.Engine.Where("role_id = ?", roleID).Count(new(UserRole))
+}
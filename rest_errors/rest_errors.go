@@ -7,6 +7,8 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Error interface {
@@ -15,6 +17,7 @@ type Error interface {
 	Error() string
 	Causes() []interface{}
 	Echo(echo.Context) error
+	GRPC() error
 }
 
 type restErr struct {
@@ -42,7 +45,36 @@ func (e restErr) Causes() []interface{} {
 }
 
 func (e restErr) Echo(c echo.Context) error {
-	return c.JSON(e.ErrStatus, echo.Map{"message": e.ErrMessage})
+	body := echo.Map{"message": e.ErrMessage}
+
+	// causes of server errors are internal
+	if len(e.ErrCauses) > 0 && e.ErrStatus < http.StatusInternalServerError {
+		body["causes"] = e.ErrCauses
+	}
+
+	return c.JSON(e.ErrStatus, body)
+}
+
+// GRPC converts the error to a gRPC status error with the matching code
+func (e restErr) GRPC() error {
+	code := codes.Internal
+
+	switch e.ErrStatus {
+	case http.StatusBadRequest, http.StatusNotAcceptable:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	}
+
+	return status.Error(code, e.ErrMessage)
 }
 
 func NewRestError(message string, status int, err string, causes []interface{}) Error {
@@ -78,6 +110,15 @@ func NewNotAcceptableError(message string) Error {
 	}
 }
 
+func NewValidationError(message string, causes []interface{}) Error {
+	return restErr{
+		ErrMessage: message,
+		ErrStatus:  http.StatusNotAcceptable,
+		ErrError:   "validation_error",
+		ErrCauses:  causes,
+	}
+}
+
 func NewNotFoundError(message string) Error {
 	return restErr{
 		ErrMessage: message,
@@ -94,6 +135,14 @@ func NewUnauthorizedError(message string) Error {
 	}
 }
 
+func NewTooManyRequestsError(message string) Error {
+	return restErr{
+		ErrMessage: message,
+		ErrStatus:  http.StatusTooManyRequests,
+		ErrError:   "too_many_requests",
+	}
+}
+
 func NewInternalServerError(message string, err error) Error {
 	result := restErr{
 		ErrMessage: message,
@@ -0,0 +1,111 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/boof/umg/application"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+)
+
+func TestSearchUsers(t *testing.T) {
+	admin, token := adminToken(t)
+	e := application.NewServer()
+
+	suffix := admin.Username[len("injection"):]
+	user := &users.User{
+		Username: "jsmith" + suffix,
+		Password: "search-pass",
+		Email:    "jsmith" + suffix + "@example.com",
+		Name:     "Jonathan Smith",
+		Company:  "Quillfeather" + suffix,
+		Address1: "12 Ravenmoor" + suffix + " Street",
+	}
+	if err := user.Save(); err != nil {
+		t.Fatalf("unable to create user: %v", err)
+	}
+	t.Cleanup(func() { user.RemoveByID() })
+
+	search := func(params string) ([]users.RoleUser, string) {
+		t.Helper()
+
+		req := httptest.NewRequest(http.MethodGet, settings.Conf.BaseURL+"search/users?"+params, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("search %s: status %d %s", params, rec.Code, rec.Body.String())
+		}
+
+		var res []users.RoleUser
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("search %s: %v", params, err)
+		}
+
+		return res, rec.Header().Get("X-Pagination-Page-Count")
+	}
+
+	has := func(res []users.RoleUser, id int64) bool {
+		for _, u := range res {
+			if u.ID == id {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	matches := []string{
+		"quillfeather" + suffix,             // company
+		"ravenmoor" + suffix,                // address
+		"jsmith" + suffix[:4],               // substring of username
+		"Quilfeather" + suffix,              // misspelled
+		"jonathan smith ravenmoor" + suffix, // words of different fields
+	}
+
+	for _, text := range matches {
+		res, pages := search("text=" + url.QueryEscape(text))
+		if !has(res, user.ID) {
+			t.Errorf("search %q didn't match the user", text)
+		}
+
+		if pages == "" {
+			t.Errorf("search %q didn't set the page count", text)
+		}
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&role=" + fmt.Sprint(1<<40)); has(res, user.ID) {
+		t.Error("role filter matched a user without the role")
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&expire=none"); !has(res, user.ID) {
+		t.Error("expire filter didn't match a user without access expiry")
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&expire=expired"); has(res, user.ID) {
+		t.Error("expire filter matched a user without access expiry")
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&online=false"); !has(res, user.ID) {
+		t.Error("online filter didn't match an offline user")
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&online=true"); has(res, user.ID) {
+		t.Error("online filter matched an offline user")
+	}
+
+	if res, _ := search("text=quillfeather" + suffix + "&last_login_from=2000-01-01T00:00:00"); has(res, user.ID) {
+		t.Error("last login filter matched a user that never logged in")
+	}
+}
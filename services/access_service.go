@@ -5,6 +5,16 @@ import (
 	"github.com/boof/umg/rest_errors"
 )
 
+// GetExpire returns access expire of the user
+func GetExpire(userID int64) (*access.Expire, rest_errors.Error) {
+	expire, err := (&access.Expire{UserID: userID}).GetByUserID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError(err.Error())
+	}
+
+	return expire, nil
+}
+
 func AddExpire(expire *access.Expire) rest_errors.Error {
 	if err := expire.Save(); err != nil {
 		return rest_errors.NewNotAcceptableError(err.Error())
@@ -1,128 +1,380 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
-	"github.com/boof/umg/rbac/access"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
 	"github.com/boof/umg/rbac/domains"
-	"github.com/boof/umg/rbac/policies"
 	"github.com/boof/umg/rbac/products"
 	"github.com/boof/umg/rbac/properties"
-	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/rest_errors"
+	"github.com/boof/umg/tracing"
+	"github.com/boof/umg/util/action"
+	"github.com/boof/umg/util/condition"
 )
 
-func HasProdPerm(user *users.User, domName, prodName, action string) bool {
-	if ok, _ := Expired(user.ID); ok {
+// PermCheck is a single check of a batch, domain checks don't have a product
+// and property checks only have the property id and type
+type PermCheck struct {
+	Domain       string
+	Product      string
+	Action       string
+	PropertyID   int64
+	PropertyType string
+}
+
+// AllowedProduct is a product and the actions that a user can carry out on it
+type AllowedProduct struct {
+	Product string
+	Actions []string
+}
+
+func HasProdPerm(ctx context.Context, user *users.User, domName, prodName, action string, cond *condition.Context) bool {
+	defer metrics.ObservePermissionCheck(metrics.ProductCheck, time.Now())
+
+	ctx, span := tracing.Start(ctx, "services.HasProdPerm", trace.WithAttributes(
+		attribute.String("domain", domName),
+		attribute.String("product", prodName),
+		attribute.String("action", action),
+	))
+	defer span.End()
+
+	perms, err := getPermissions(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error("unable to get user permissions", zap.Int64("user_id", user.ID), zap.Error(err))
 		return false
 	}
 
-	dom, err := (&domains.Domain{Name: domName}).GetByName()
+	allowed := perms.hasProdPerm(domName, prodName, action, cond)
+	checkDone(ctx, span, user, allowed, zap.String("domain", domName), zap.String("product", prodName), zap.String("action", action))
+
+	return allowed
+}
+
+func HasDomPerm(ctx context.Context, user *users.User, domName string, action string, cond *condition.Context) bool {
+	defer metrics.ObservePermissionCheck(metrics.DomainCheck, time.Now())
+
+	ctx, span := tracing.Start(ctx, "services.HasDomPerm", trace.WithAttributes(
+		attribute.String("domain", domName),
+		attribute.String("action", action),
+	))
+	defer span.End()
+
+	perms, err := getPermissions(ctx, user)
 	if err != nil {
+		logger.FromContext(ctx).Error("unable to get user permissions", zap.Int64("user_id", user.ID), zap.Error(err))
 		return false
 	}
 
-	prod := &products.Product{DomainID: dom.ID, Name: prodName}
-	if has, err := db.Engine.Get(prod); !has || err != nil {
+	allowed := perms.hasDomPerm(domName, action, cond)
+	checkDone(ctx, span, user, allowed, zap.String("domain", domName), zap.String("action", action))
+
+	return allowed
+}
+
+func HasPropertyPerm(ctx context.Context, user *users.User, meteringID int64, pType string) bool {
+	defer metrics.ObservePermissionCheck(metrics.PropertyCheck, time.Now())
+
+	ctx, span := tracing.Start(ctx, "services.HasPropertyPerm", trace.WithAttributes(
+		attribute.Int64("property_id", meteringID),
+		attribute.String("property_type", pType),
+	))
+	defer span.End()
+
+	perms, err := getPermissions(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error("unable to get user permissions", zap.Int64("user_id", user.ID), zap.Error(err))
 		return false
 	}
 
-	has, _ := hasProdPerm(user, dom.ID, prod.ID, action)
-	return has
+	allowed := perms.hasPropertyPerm(meteringID, pType)
+	checkDone(ctx, span, user, allowed, zap.Int64("property_id", meteringID), zap.String("property_type", pType))
+
+	return allowed
 }
 
-func HasDomPerm(user *users.User, domName string, action string) bool {
-	if ok, _ := access.Expired(user.ID); ok {
-		return false
+// MaxBatchChecks is the maximum number of checks of a batch
+const MaxBatchChecks = 100
+
+// BatchCheck checks many permissions of the user with a single lookup of
+// the user's permissions, results are in the order of checks
+func BatchCheck(ctx context.Context, user *users.User, checks []PermCheck, cond *condition.Context) ([]bool, rest_errors.Error) {
+	if len(checks) > MaxBatchChecks {
+		return nil, rest_errors.NewBadRequestError(fmt.Sprintf("a batch can't have more than %d checks", MaxBatchChecks))
 	}
 
-	dom, err := (&domains.Domain{Name: domName}).GetByName()
+	defer metrics.ObservePermissionCheck(metrics.BatchCheck, time.Now())
+
+	ctx, span := tracing.Start(ctx, "services.BatchCheck", trace.WithAttributes(attribute.Int("checks", len(checks))))
+	defer span.End()
+
+	perms, err := getPermissions(ctx, user)
 	if err != nil {
-		return false
+		return nil, rest_errors.NewInternalServerError("Unable to get user permissions", err)
+	}
+
+	res := make([]bool, len(checks))
+
+	allowed := 0
+	for i, check := range checks {
+		if check.PropertyType != "" {
+			res[i] = perms.hasPropertyPerm(check.PropertyID, check.PropertyType)
+		} else if check.Product != "" {
+			res[i] = perms.hasProdPerm(check.Domain, check.Product, check.Action, cond)
+		} else {
+			res[i] = perms.hasDomPerm(check.Domain, check.Action, cond)
+		}
+
+		if res[i] {
+			allowed++
+		}
 	}
 
-	has, _ := hasDomPerm(user, dom.ID, action)
-	return has
+	span.SetAttributes(attribute.Int("allowed", allowed))
+	logger.FromContext(ctx).Debug("batch permission check", zap.Int64("user_id", user.ID),
+		zap.Int("checks", len(checks)), zap.Int("allowed", allowed))
+
+	return res, nil
 }
 
-func HasPropertyPerm(user *users.User, meteringID int64, pType string) bool {
-	property, err := (&properties.Property{MeteringID: meteringID, Type: pType}).GetByMeteringID()
+// ListAllowed returns the actions that the user can carry out on the domain and
+// on each product of it. Actions of the domain's catalogue are checked one by one,
+// domains without catalogue return action patterns of the user's policies that
+// no deny policy applies to any part of
+func ListAllowed(ctx context.Context, user *users.User, domName string, cond *condition.Context) ([]string, []AllowedProduct, rest_errors.Error) {
+	ctx, span := tracing.Start(ctx, "services.ListAllowed", trace.WithAttributes(attribute.String("domain", domName)))
+	defer span.End()
+
+	perms, err := getPermissions(ctx, user)
 	if err != nil {
-		return false
+		return nil, nil, rest_errors.NewInternalServerError("Unable to get user permissions", err)
 	}
 
-	for _, roleID := range user.RoleIDs {
-		r, err := (&roles.Role{ID: roleID}).GetByID()
-		if err == nil && r.IsAdmin() {
-			return true
+	domActions := make([]string, 0)
+	allowed := make([]AllowedProduct, 0)
+
+	if perms.expired() {
+		return domActions, allowed, nil
+	}
+
+	dom, err := (&domains.Domain{Name: domName}).GetByName()
+	if err != nil {
+		return nil, nil, rest_errors.NewNotFoundError("Domain not found")
+	}
+
+	candidates, err := perms.candidateActions(dom.ID, cond)
+	if err != nil {
+		return nil, nil, rest_errors.NewInternalServerError("Unable to get domain actions", err)
+	}
+
+	for _, act := range candidates {
+		if perms.Admin || (perms.hasDomPerm(domName, act, cond) && !perms.partlyDenied(domName, "", act, cond)) {
+			domActions = append(domActions, act)
 		}
+	}
+
+	prodNames, err := perms.productNames(dom)
+	if err != nil {
+		return nil, nil, rest_errors.NewInternalServerError("Unable to get domain products", err)
+	}
 
-		//policies, err := (&roles.Role{ID: roleID}).GetPolicies()
-		pols, err := (&policies.Policy{RoleID: roleID}).GetRolePolicies()
-		if err == nil {
-			for _, policy := range pols {
-				for _, pID := range policy.Properties {
-					if pID == property.ID {
-						return true
-					}
-				}
+	for _, prodName := range prodNames {
+		prodActions := make([]string, 0)
+		for _, act := range candidates {
+			// products of admins are read from the domain so they exist
+			if perms.Admin || (perms.hasProdPerm(domName, prodName, act, cond) && !perms.partlyDenied(domName, prodName, act, cond)) {
+				prodActions = append(prodActions, act)
 			}
 		}
+
+		if len(prodActions) > 0 {
+			allowed = append(allowed, AllowedProduct{Product: prodName, Actions: prodActions})
+		}
 	}
 
-	return false
+	return domActions, allowed, nil
+}
+
+func (perms *permissions) hasProdPerm(domName, prodName, action string, ctx *condition.Context) bool {
+	var allowed bool
+
+	switch {
+	case perms.expired():
+	case perms.Admin:
+		allowed = productExists(domName, prodName)
+	default:
+		allowed = perms.letProdAct(domName, prodName, action, ctx)
+	}
+
+	metrics.PermissionCheck(metrics.ProductCheck, perms.domainLabel(domName, allowed), allowed)
+	return allowed
 }
 
-func hasProdPerm(user *users.User, domID, prodID int64, action string) (bool, rest_errors.Error) {
-	// validate product
-	if has, _ := db.Engine.ID(prodID).Get(&products.Product{}); !has {
-		return false, rest_errors.NewNotFoundError("Invalid product")
+func (perms *permissions) hasDomPerm(domName, action string, ctx *condition.Context) bool {
+	var allowed bool
+
+	switch {
+	case perms.expired():
+	case perms.Admin:
+		_, err := (&domains.Domain{Name: domName}).GetByName()
+		allowed = err == nil
+	default:
+		allowed = perms.letDomAct(domName, action, ctx)
+	}
+
+	metrics.PermissionCheck(metrics.DomainCheck, perms.domainLabel(domName, allowed), allowed)
+	return allowed
+}
+
+func (perms *permissions) hasPropertyPerm(meteringID int64, pType string) bool {
+	var allowed bool
+
+	if perms.Admin {
+		_, err := (&properties.Property{MeteringID: meteringID, Type: pType}).GetByMeteringID()
+		allowed = err == nil
+	} else {
+		allowed = perms.Properties[propertyKey(meteringID, pType)]
+	}
+
+	metrics.PermissionCheck(metrics.PropertyCheck, "", allowed)
+	return allowed
+}
+
+// checkDone records the decision of a permission check on its span and in the debug logs
+func checkDone(ctx context.Context, span trace.Span, user *users.User, allowed bool, fields ...zap.Field) {
+	span.SetAttributes(attribute.Int64("user_id", user.ID), attribute.Bool("allowed", allowed))
+
+	fields = append(fields, zap.Int64("user_id", user.ID), zap.Bool("allowed", allowed))
+	logger.FromContext(ctx).Debug("permission check", fields...)
+}
+
+// domainLabel returns the domain name for metrics of a check, names of the
+// domains that the user doesn't have any policy in come from clients so
+// they're grouped to keep the labels bounded
+func (perms *permissions) domainLabel(domName string, allowed bool) string {
+	if _, ok := perms.Domains[domName]; ok || (perms.Admin && allowed) {
+		return domName
+	}
+
+	return metrics.OtherDomain
+}
+
+// candidateActions returns the catalogue of the domain, or the action patterns
+// of the allow policies of the domain that apply to the request
+func (perms *permissions) candidateActions(domID int64, ctx *condition.Context) ([]string, error) {
+	catalogue, err := domains.GetActions(domID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0)
+	for _, act := range catalogue {
+		res = append(res, act.Name)
 	}
 
-	// check for permission
-	// allow-override algorithm
-	for _, roleID := range user.RoleIDs {
-		r, err := (&roles.Role{ID: roleID}).GetByID()
-		if err == nil && r.IsAdmin() {
-			return true, nil
+	if len(res) > 0 {
+		return res, nil
+	}
+
+	if perms.Admin {
+		return []string{action.Wildcard}, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range perms.Policies {
+		if p.DomainID != domID || p.IsDeny() {
+			continue
 		}
 
-		allPolicies, err := policies.GetByRole(roleID)
-		if err == nil {
-			for _, p := range allPolicies {
-				if p.LetProdAct(domID, prodID, action) {
-					return true, nil
-				}
+		if ok, err := condition.Evaluate(p.Condition, ctx); err != nil || !ok {
+			continue
+		}
+
+		for _, act := range p.Actions {
+			act = strings.ToLower(act)
+			if !seen[act] {
+				seen[act] = true
+				res = append(res, act)
 			}
 		}
 	}
 
-	return false, nil
+	sort.Strings(res)
+	return res, nil
 }
 
-func hasDomPerm(user *users.User, domID int64, action string) (bool, error) {
-	// validate domain
-	if has, _ := db.Engine.ID(domID).Get(&domains.Domain{}); !has {
-		return false, rest_errors.NewNotFoundError("Invalid domain")
+// partlyDenied indicates that a deny policy applies to some actions of an
+// allowed action pattern, so the pattern isn't allowed as a whole. The domain
+// is the scope when the product is empty
+func (perms *permissions) partlyDenied(domName, prodName, pattern string, ctx *condition.Context) bool {
+	domID := perms.Domains[domName]
+	if !action.IsPattern(pattern) || perms.Algorithms[domID] == domains.AllowOverrides {
+		return false
 	}
 
-	// check for permission
-	// allow-override algorithm
-	for _, roleID := range user.RoleIDs {
-		r, err := (&roles.Role{ID: roleID}).GetByID()
-		if err == nil && r.IsAdmin() {
-			return true, nil
+	prodID := perms.Products[productKey(domName, prodName)]
+	for _, p := range perms.Policies {
+		if !p.IsDeny() {
+			continue
 		}
 
-		rolePolicies, err := policies.GetByRole(roleID)
-		if err == nil {
-			for _, p := range rolePolicies {
-				if p.LetDomAct(domID, action) {
-					return true, nil
-				}
+		if prodName == "" && p.OverlapsDomAct(domID, pattern, ctx) {
+			return true
+		} else if prodName != "" && p.OverlapsProdAct(domID, prodID, pattern, ctx) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// productNames returns names of the products of the domain that the user
+// may have access to
+func (perms *permissions) productNames(dom *domains.Domain) ([]string, error) {
+	res := make([]string, 0)
+
+	if perms.Admin {
+		prods, err := products.GetProductsByDomain(dom.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, prod := range prods {
+			res = append(res, prod.Name)
+		}
+	} else {
+		prefix := productKey(dom.Name, "")
+		for key := range perms.Products {
+			if strings.HasPrefix(key, prefix) {
+				res = append(res, strings.TrimPrefix(key, prefix))
 			}
 		}
 	}
 
-	return false, nil
+	sort.Strings(res)
+	return res, nil
+}
+
+// productExists indicates that the product exists in the domain or not,
+// checks of admins are not compiled so they need it
+func productExists(domName, prodName string) bool {
+	dom, err := (&domains.Domain{Name: domName}).GetByName()
+	if err != nil {
+		return false
+	}
+
+	prod := &products.Product{DomainID: dom.ID, Name: prodName}
+	has, err := db.Engine.Get(prod)
+	return has && err == nil
 }
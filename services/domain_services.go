@@ -13,3 +13,73 @@ func GetAllDomains() ([]domains.Domain, rest_errors.Error) {
 
 	return res, nil
 }
+
+// GetDomainByID returns the domain with the given id
+func GetDomainByID(domainID int64) (*domains.Domain, rest_errors.Error) {
+	domain, err := (&domains.Domain{ID: domainID}).GetByID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError(err.Error())
+	}
+
+	return domain, nil
+}
+
+// AddDomain inserts a new domain
+func AddDomain(domain *domains.Domain) rest_errors.Error {
+	if err := domain.Save(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// SetDomainAlgorithm changes the combining algorithm of the domain
+func SetDomainAlgorithm(domain *domains.Domain) rest_errors.Error {
+	if err := domain.UpdateAlgorithm(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// GetDomainActions returns the action catalogue of the domain
+func GetDomainActions(domainID int64) ([]domains.Action, rest_errors.Error) {
+	actions, err := domains.GetActions(domainID)
+	if err != nil {
+		return nil, rest_errors.NewInternalServerError("Unable to get actions", err)
+	}
+
+	if actions == nil {
+		actions = make([]domains.Action, 0)
+	}
+
+	return actions, nil
+}
+
+// GetDomainActionByID returns the catalogue action with the given id
+func GetDomainActionByID(actionID int64) (*domains.Action, rest_errors.Error) {
+	act, err := (&domains.Action{ID: actionID}).GetByID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError(err.Error())
+	}
+
+	return act, nil
+}
+
+// AddDomainAction registers an action in the catalogue of its domain
+func AddDomainAction(act *domains.Action) rest_errors.Error {
+	if err := act.Save(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// RemoveDomainAction removes an action from the catalogue of its domain
+func RemoveDomainAction(act *domains.Action) rest_errors.Error {
+	if err := act.RemoveByID(); err != nil {
+		return rest_errors.NewInternalServerError(err.Error(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/rbac/access"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/rest_errors"
+	"github.com/boof/umg/settings"
+)
+
+// loginLocked returns a rest error if the username or the IP address is locked
+func loginLocked(username, ip string) rest_errors.Error {
+	for _, key := range []string{userLockKey(username), ipLockKey(ip)} {
+		remaining, err := db.LoginLockedFor(key)
+		if err != nil {
+			logger.Log.Error("error while checking login lock", zap.Error(err))
+			continue
+		}
+
+		if remaining > 0 {
+			minutes := int(math.Ceil(remaining.Minutes()))
+			return rest_errors.NewTooManyRequestsError(
+				fmt.Sprintf("Too many failed login attempts, try again in %d minutes.", minutes))
+		}
+	}
+
+	return nil
+}
+
+// registerLoginFailure counts a failed login for the username and the IP address,
+// and locks them with exponential backoff after passing the thresholds
+func registerLoginFailure(username, ip string) {
+	conf := settings.Conf.Lockout
+
+	lockIfNeeded(access.LockUser, strings.ToLower(username), ip, userLockKey(username), conf.MaxAttempts)
+	lockIfNeeded(access.LockIP, ip, ip, ipLockKey(ip), conf.MaxIPAttempts)
+}
+
+func lockIfNeeded(scope, subject, ip, key string, threshold int) {
+	conf := settings.Conf.Lockout
+
+	failures, err := db.RecordLoginFailure(key, conf.Window)
+	if err != nil {
+		logger.Log.Error("error while recording login failure", zap.Error(err))
+		return
+	}
+
+	if failures < int64(threshold) {
+		return
+	}
+
+	duration := lockDuration(failures-int64(threshold), conf.Duration, conf.MaxDuration)
+	if err := db.LockLogin(key, duration); err != nil {
+		logger.Log.Error("error while locking login", zap.Error(err))
+		return
+	}
+
+	lockout := &access.Lockout{
+		Scope:       scope,
+		Subject:     subject,
+		IP:          ip,
+		Failures:    failures,
+		LockedUntil: time.Now().Add(duration),
+	}
+
+	if err := lockout.Save(); err != nil {
+		logger.Log.Error("error while saving lockout", zap.Error(err))
+	}
+}
+
+// lockDuration returns base * 2^exp limited to max
+func lockDuration(exp int64, base, max time.Duration) time.Duration {
+	duration := base
+	for i := int64(0); i < exp && duration < max; i++ {
+		duration *= 2
+	}
+
+	if duration > max {
+		return max
+	}
+
+	return duration
+}
+
+// clearLoginFailures forgets failed logins of the username after a successful login
+func clearLoginFailures(username string) {
+	if err := db.ClearLoginFailures(userLockKey(username)); err != nil {
+		logger.Log.Error("error while clearing login failures", zap.Error(err))
+	}
+}
+
+// UnlockUser removes login lock of the given user
+func UnlockUser(userID, adminID int64) rest_errors.Error {
+	user, err := (&users.User{ID: userID}).GetByID()
+	if err != nil {
+		return rest_errors.NewNotFoundError("User not found")
+	}
+
+	return unlock(access.LockUser, strings.ToLower(user.Username), userLockKey(user.Username), adminID)
+}
+
+// UnlockIP removes login lock of the given IP address
+func UnlockIP(ip string, adminID int64) rest_errors.Error {
+	return unlock(access.LockIP, ip, ipLockKey(ip), adminID)
+}
+
+func unlock(scope, subject, key string, adminID int64) rest_errors.Error {
+	if err := db.ClearLoginFailures(key); err != nil {
+		return rest_errors.NewInternalServerError("Unable to unlock", err)
+	}
+
+	if err := (&access.Lockout{Scope: scope, Subject: subject}).Unlock(adminID); err != nil {
+		return rest_errors.NewInternalServerError("Database error", err)
+	}
+
+	return nil
+}
+
+// GetLockouts returns recorded lockouts and number of pages
+func GetLockouts(count, page int64, active bool) ([]access.Lockout, int64, rest_errors.Error) {
+	lockouts, total, err := access.GetLockouts(count, page, active)
+	if err != nil {
+		return nil, 0, rest_errors.NewInternalServerError("Database error", err)
+	}
+
+	if lockouts == nil {
+		lockouts = make([]access.Lockout, 0)
+	}
+
+	pages := total / count
+	if total%count != 0 {
+		pages += 1
+	}
+
+	return lockouts, pages, nil
+}
+
+func userLockKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipLockKey(ip string) string {
+	return "ip:" + ip
+}
@@ -3,7 +3,11 @@ package services
 import (
 	"strings"
 
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
 	"github.com/boof/umg/rbac/access"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/rest_errors"
@@ -11,25 +15,75 @@ import (
 )
 
 // Login if there was a user with the current username and password
-// this functions fetch and returned it otherwise returns error
-func Login(username, pass string) (*users.User, rest_errors.Error) {
+// this functions fetch and returned it otherwise returns error.
+// Failed logins are counted per username and per ip to lock them temporarily
+func Login(username, pass, ip string) (*users.User, rest_errors.Error) {
 	if pass == "" || username == "" {
+		metrics.Login(metrics.Failure)
 		return nil, rest_errors.NewUnauthorizedError("Incorrect username or password.")
 	}
 
+	if err := loginLocked(username, ip); err != nil {
+		metrics.Login(metrics.Locked)
+		return nil, err
+	}
+
 	var user users.User
 	ok, err := db.Engine.SQL("SELECT * FROM \"user\" WHERE LOWER(username) = ?", strings.ToLower(username)).Get(&user)
 	if !ok || err != nil {
+		metrics.Login(metrics.Failure)
+		registerLoginFailure(username, ip)
 		return nil, rest_errors.NewUnauthorizedError("Incorrect username or password.")
 	}
 
+	twoFactor := false
 	if password.IsValidPass(pass, user.Password) {
+		// failures are kept and success is counted after the second factor
+		twoFactor = TwoFactorEnabled(user.ID)
+		if !twoFactor {
+			clearLoginFailures(username)
+		}
+
+		// upgrade old hashes transparently
+		if password.NeedsRehash(user.Password) {
+			if err := user.RehashPassword(pass); err != nil {
+				logger.Log.Error("unable to rehash password", zap.Int64("user_id", user.ID), zap.Error(err))
+			}
+		}
+
 		if ok, _ := access.Expired(user.ID); ok {
+			metrics.Login(metrics.Expired)
 			return nil, rest_errors.NewUnauthorizedError("Your access time is expired!")
 		}
 	} else {
+		metrics.Login(metrics.Failure)
+		registerLoginFailure(username, ip)
 		return nil, rest_errors.NewUnauthorizedError("Incorrect username or password.")
 	}
 
+	if !twoFactor {
+		metrics.Login(metrics.Success)
+	}
+
 	return &user, nil
 }
+
+// VerifyLoginTwoFactor checks the second factor of a login. Invalid codes are
+// counted as failed logins of the username and the ip address, and failures of
+// the username are cleared only after the second factor succeeds
+func VerifyLoginTwoFactor(user *users.User, code, ip string) rest_errors.Error {
+	if err := loginLocked(user.Username, ip); err != nil {
+		metrics.Login(metrics.Locked)
+		return err
+	}
+
+	if !VerifyTwoFactor(user.ID, code) {
+		metrics.Login(metrics.Failure)
+		registerLoginFailure(user.Username, ip)
+		return rest_errors.NewUnauthorizedError("Invalid two-factor code.")
+	}
+
+	clearLoginFailures(user.Username)
+	metrics.Login(metrics.Success)
+	return nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/rest_errors"
+	"github.com/boof/umg/util/password"
+)
+
+// ChangePassword changes password of the given user if it satisfies the password policy
+func ChangePassword(userID int64, pass string) rest_errors.Error {
+	user, err := (&users.User{ID: userID}).GetByID()
+	if err != nil {
+		return rest_errors.NewNotFoundError("User not found")
+	}
+
+	if err := validatePassword(user, pass); err != nil {
+		return err
+	}
+
+	if user.UsedRecently(pass) {
+		violation := password.ReusedViolation()
+		return rest_errors.NewValidationError("Password doesn't satisfy the password policy", violation.Causes())
+	}
+
+	if err := user.ChangePassword(pass); err != nil {
+		return rest_errors.NewNotAcceptableError(err.Error())
+	}
+
+	return nil
+}
+
+// validatePassword checks the password of the user against the password policy
+func validatePassword(user *users.User, pass string) rest_errors.Error {
+	if violation := password.ValidatePolicy(pass, user.Username); violation != nil {
+		return rest_errors.NewValidationError("Password doesn't satisfy the password policy", violation.Causes())
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/boof/umg/db"
+	"github.com/boof/umg/logger"
+	"github.com/boof/umg/metrics"
+	"github.com/boof/umg/rbac/access"
+	"github.com/boof/umg/rbac/domains"
+	"github.com/boof/umg/rbac/policies"
+	"github.com/boof/umg/rbac/products"
+	"github.com/boof/umg/rbac/properties"
+	"github.com/boof/umg/rbac/roles"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/tracing"
+	"github.com/boof/umg/util/condition"
+	"github.com/boof/umg/util/datetime"
+)
+
+// permissions are the effective permissions of a user, compiled from the
+// user's roles and policies so that a check needs a single cache lookup
+type permissions struct {
+	Admin    bool       `json:"admin"`
+	ExpireAt *time.Time `json:"expire_at,omitempty"`
+
+	// ids of the domains and products that appear in user's policies by name,
+	// products of domains with `All Product Policy` are included
+	Domains  map[string]int64 `json:"domains"`
+	Products map[string]int64 `json:"products"`
+
+	// combining algorithms of the domains by id
+	Algorithms map[int64]string `json:"algorithms"`
+
+	// properties of user's policies by type and metering id
+	Properties map[string]bool `json:"properties"`
+
+	Policies []policies.Policy `json:"policies"`
+}
+
+// getPermissions returns the cached permissions of the user, permissions are
+// compiled and cached on a miss
+func getPermissions(ctx context.Context, user *users.User) (*permissions, error) {
+	ctx, span := tracing.Start(ctx, "services.getPermissions", trace.WithAttributes(attribute.Int64("user_id", user.ID)))
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	data, found, err := db.GetPermissions(user.ID)
+	if err != nil {
+		log.Warn("unable to get cached permissions", zap.Int64("user_id", user.ID), zap.Error(err))
+	}
+
+	if found {
+		perms := new(permissions)
+		if err := json.Unmarshal(data, perms); err == nil {
+			metrics.PermissionCache(true)
+			span.SetAttributes(attribute.Bool("cache_hit", true))
+			return perms, nil
+		}
+	}
+
+	metrics.PermissionCache(false)
+	span.SetAttributes(attribute.Bool("cache_hit", false))
+
+	// permissions that are compiled during an invalidation aren't cached
+	generation, genErr := db.PermissionsGeneration(user.ID)
+	if genErr != nil {
+		log.Warn("unable to get permissions generation", zap.Int64("user_id", user.ID), zap.Error(genErr))
+	}
+
+	perms, err := compilePermissions(user)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(perms); err == nil && genErr == nil {
+		if err := db.SetPermissions(user.ID, generation, data, settings.Conf.Cache.PermissionsTTL); err != nil {
+			log.Warn("unable to cache permissions", zap.Int64("user_id", user.ID), zap.Error(err))
+		}
+	}
+
+	return perms, nil
+}
+
+func compilePermissions(user *users.User) (*permissions, error) {
+	perms := &permissions{
+		Domains:    make(map[string]int64),
+		Products:   make(map[string]int64),
+		Algorithms: make(map[int64]string),
+		Properties: make(map[string]bool),
+		Policies:   make([]policies.Policy, 0),
+	}
+
+	if expire, err := (&access.Expire{UserID: user.ID}).GetByUserID(); err == nil {
+		perms.ExpireAt = &expire.ExpireAt
+	}
+
+	roleIDs, err := user.GetRoleIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roleID := range roles.ResolveIDs(roleIDs) {
+		r, err := (&roles.Role{ID: roleID}).GetByID()
+		if err == nil && r.IsAdmin() {
+			perms.Admin = true
+			continue
+		}
+
+		rolePolicies, err := policies.GetByRole(roleID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range rolePolicies {
+			if err := perms.addPolicy(p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+func (perms *permissions) addPolicy(p policies.Policy) error {
+	dom, err := (&domains.Domain{ID: p.DomainID}).GetByID()
+	if err != nil {
+		// policies of removed domains don't let anything
+		return nil
+	}
+	perms.Domains[dom.Name] = dom.ID
+	perms.Algorithms[dom.ID] = dom.Algorithm
+
+	if p.IsProductPolicy() {
+		if prod, err := (&products.Product{ID: p.ProductID}).GetByID(); err == nil {
+			perms.Products[productKey(dom.Name, prod.Name)] = prod.ID
+		}
+	} else if p.IsAllProductPolicy() {
+		prods, err := products.GetProductsByDomain(dom.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, prod := range prods {
+			perms.Products[productKey(dom.Name, prod.Name)] = prod.ID
+		}
+	}
+
+	// properties are only granted by allow policies
+	for _, pID := range p.Properties {
+		if property, err := (&properties.Property{ID: pID}).GetByID(); err == nil && !p.IsDeny() {
+			perms.Properties[propertyKey(property.MeteringID, property.Type)] = true
+		}
+	}
+
+	perms.Policies = append(perms.Policies, p)
+	return nil
+}
+
+func (perms *permissions) expired() bool {
+	return perms.ExpireAt != nil && perms.ExpireAt.Before(datetime.NowInEasternCanada())
+}
+
+func (perms *permissions) letProdAct(domName, prodName, action string, ctx *condition.Context) bool {
+	domID, ok := perms.Domains[domName]
+	if !ok {
+		return false
+	}
+
+	prodID, ok := perms.Products[productKey(domName, prodName)]
+	if !ok {
+		return false
+	}
+
+	allowed, denied := false, false
+	for _, p := range perms.Policies {
+		if p.MatchProdAct(domID, prodID, action, ctx) {
+			if p.IsDeny() {
+				denied = true
+			} else {
+				allowed = true
+			}
+		}
+	}
+
+	return domains.Combine(perms.Algorithms[domID], allowed, denied)
+}
+
+func (perms *permissions) letDomAct(domName, action string, ctx *condition.Context) bool {
+	domID, ok := perms.Domains[domName]
+	if !ok {
+		return false
+	}
+
+	allowed, denied := false, false
+	for _, p := range perms.Policies {
+		if p.MatchDomAct(domID, action, ctx) {
+			if p.IsDeny() {
+				denied = true
+			} else {
+				allowed = true
+			}
+		}
+	}
+
+	return domains.Combine(perms.Algorithms[domID], allowed, denied)
+}
+
+// NewRequestContext returns the context that policy conditions of a request
+// are evaluated against, time is the local time of the request
+func NewRequestContext(ip string, attributes map[string]string) *condition.Context {
+	return &condition.Context{
+		Time:       datetime.NowInEasternCanada(),
+		IP:         ip,
+		Attributes: attributes,
+	}
+}
+
+func productKey(domName, prodName string) string {
+	return domName + "/" + prodName
+}
+
+func propertyKey(meteringID int64, pType string) string {
+	return fmt.Sprintf("%s/%d", pType, meteringID)
+}
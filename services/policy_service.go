@@ -6,17 +6,50 @@ import (
 	"github.com/boof/umg/rbac/policies"
 	"github.com/boof/umg/rbac/products"
 	"github.com/boof/umg/rbac/properties"
+	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rest_errors"
 )
 
+// GetNamedPolicies returns the effective policies of the role, its own policies
+// and the ones that it inherits from its ancestors
 func GetNamedPolicies(roleID int64) ([]map[string]interface{}, error) {
-	res := make([]map[string]interface{}, 0)
+	all, err := GetEffectivePolicies(roleID)
+	if err != nil {
+		return make([]map[string]interface{}, 0), err
+	}
 
+	return namePolicies(all), nil
+}
+
+// GetDirectNamedPolicies returns the policies that are added to the role itself
+func GetDirectNamedPolicies(roleID int64) ([]map[string]interface{}, error) {
 	all, err := policies.GetByRole(roleID)
 	if err != nil {
-		return res, err
+		return make([]map[string]interface{}, 0), err
+	}
+
+	return namePolicies(all), nil
+}
+
+// GetEffectivePolicies returns the policies of the role and its ancestors
+func GetEffectivePolicies(roleID int64) ([]policies.Policy, error) {
+	res := make([]policies.Policy, 0)
+
+	for _, id := range roles.ResolveIDs([]int64{roleID}) {
+		rolePolicies, err := policies.GetByRole(id)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, rolePolicies...)
 	}
 
+	return res, nil
+}
+
+func namePolicies(all []policies.Policy) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0)
+
 	for _, policy := range all {
 		p := make(map[string]interface{})
 
@@ -56,7 +89,10 @@ func GetNamedPolicies(roleID int64) ([]map[string]interface{}, error) {
 		}
 
 		p["id"] = policy.ID
+		p["role_id"] = policy.RoleID
 		p["type"] = policy.Type
+		p["effect"] = policy.Effect
+		p["condition"] = policy.Condition
 
 		props := make([]*properties.Property, 0)
 		for _, pID := range policy.Properties {
@@ -70,14 +106,67 @@ func GetNamedPolicies(roleID int64) ([]map[string]interface{}, error) {
 		res = append(res, p)
 	}
 
+	return res
+}
+
+// GetRolePolicies returns policies of the role, including the inherited ones
+// when effective is true
+func GetRolePolicies(roleID int64, effective bool) ([]policies.Policy, rest_errors.Error) {
+	var res []policies.Policy
+	var err error
+
+	if effective {
+		res, err = GetEffectivePolicies(roleID)
+	} else {
+		res, err = (&policies.Policy{RoleID: roleID}).GetRolePolicies()
+	}
+
+	if err != nil {
+		return nil, rest_errors.NewInternalServerError("Unable to get policies", err)
+	}
+
+	if res == nil {
+		res = make([]policies.Policy, 0)
+	}
+
 	return res, nil
 }
 
+// GetPolicyByID returns the policy with the given id
+func GetPolicyByID(policyID int64) (*policies.Policy, rest_errors.Error) {
+	policy, err := (&policies.Policy{ID: policyID}).GetByID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError(err.Error())
+	}
+
+	return policy, nil
+}
+
+// AddPolicy inserts a new policy for its role
+func AddPolicy(policy *policies.Policy) rest_errors.Error {
+	if err := policy.Save(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// RemovePolicy removes the policy from its role
+func RemovePolicy(policy *policies.Policy) rest_errors.Error {
+	if err := policy.RemoveByID(); err != nil {
+		return rest_errors.NewInternalServerError(err.Error(), err)
+	}
+
+	return nil
+}
+
 func removePoliciesByRole(roleID int64) rest_errors.Error {
 	_, err := db.Engine.Delete(&policies.Policy{RoleID: roleID})
 	if err != nil {
 		return rest_errors.NewInternalServerError(err.Error(), err)
 	}
 
+	db.InvalidateAllPermissions()
+
 	return nil
 }
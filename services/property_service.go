@@ -4,6 +4,7 @@ import (
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/policies"
 	"github.com/boof/umg/rbac/properties"
+	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/rest_errors"
 )
@@ -14,10 +15,14 @@ func GetProperties(user *users.User) ([]properties.Property, rest_errors.Error)
 	}
 
 	props := make([]properties.Property, 0)
-	for _, roleID := range user.RoleIDs {
+	for _, roleID := range roles.ResolveIDs(user.RoleIDs) {
 		policies, err := (&policies.Policy{RoleID: roleID}).GetRolePolicies()
 		if err == nil {
 			for _, policy := range policies {
+				if policy.IsDeny() {
+					continue
+				}
+
 				for _, pID := range policy.Properties {
 					if property, err := (&properties.Property{ID: pID}).GetByID(); err == nil {
 						has := false
@@ -49,11 +54,11 @@ func GetPropertiesForDomain(user *users.User, domainName string) ([]properties.P
 	}
 
 	props := make([]properties.Property, 0)
-	for _, roleID := range user.RoleIDs {
+	for _, roleID := range roles.ResolveIDs(user.RoleIDs) {
 		pols, err := (&policies.Policy{RoleID: roleID}).GetRolePolicies()
 		if err == nil {
 			for _, policy := range pols {
-				if policy.DomainID == domain.ID {
+				if policy.DomainID == domain.ID && !policy.IsDeny() {
 					for _, pID := range policy.Properties {
 						if property, err := (&properties.Property{ID: pID}).GetByID(); err == nil {
 							has := false
@@ -1,8 +1,8 @@
 package services
 
 import (
-	"fmt"
 	"github.com/boof/umg/db"
+	"github.com/boof/umg/rbac/policies"
 	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rbac/users"
 	"github.com/boof/umg/rest_errors"
@@ -17,19 +17,125 @@ func GetAllRoles(count, page int64, order, sortBy string) ([]roles.Role, rest_er
 	return res, nil
 }
 
+// GetRoleByID returns the role with the given id
+func GetRoleByID(roleID int64) (*roles.Role, rest_errors.Error) {
+	role, err := (&roles.Role{ID: roleID}).GetByID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError("Role not found")
+	}
+
+	return role, nil
+}
+
+// AddRole inserts a new role without any policy
+func AddRole(role *roles.Role) rest_errors.Error {
+	if err := role.Save(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// AddRoleWithPolicies inserts a new role with its policies, nothing is saved
+// if any of the policies is invalid
+func AddRoleWithPolicies(role *roles.Role, pols []policies.Policy) ([]policies.Policy, rest_errors.Error) {
+	if err := role.Save(); err != nil {
+		return nil, rest_errors.NewBadRequestError(err.Error())
+	}
+
+	saved := make([]policies.Policy, 0)
+	for _, policy := range pols {
+		policy.RoleID = role.ID
+		err := policy.Save()
+		if err != nil {
+			for _, s := range saved {
+				s.RemoveByID()
+			}
+
+			RemoveRoleByID(role.ID)
+
+			return nil, rest_errors.NewBadRequestError(err.Error())
+		}
+
+		saved = append(saved, policy)
+	}
+
+	return saved, nil
+}
+
+// UpdateRole updates name and parents of the role
+func UpdateRole(role *roles.Role) rest_errors.Error {
+	if err := role.Update(); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// AssignRole adds the role to roles of the user
+func AssignRole(user *users.User, roleID int64) rest_errors.Error {
+	if err := user.AssignRole(roleID); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// DisallowRole removes the role from roles of the user
+func DisallowRole(user *users.User, roleID int64) rest_errors.Error {
+	if err := user.DisallowRole(roleID); err != nil {
+		return rest_errors.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
 func RemoveRoleByID(roleID int64) rest_errors.Error {
 	removePoliciesByRole(roleID)
 
-	var allUsers []users.User
-	query := fmt.Sprintf("SELECT * FROM \"user\" WHERE role_ids LIKE '%%%d,%%' OR role_ids LIKE '%%%d]%%'", roleID, roleID)
-	db.Engine.SQL(query).Find(&allUsers)
-	for _, user := range allUsers {
-		user.DisallowRole(roleID)
+	userIDs, err := users.GetIDsByRole(roleID)
+	if err != nil {
+		return rest_errors.NewInternalServerError("Unable to find users of the role", err)
+	}
+
+	for _, userID := range userIDs {
+		(&users.User{ID: userID}).DisallowRole(roleID)
+	}
+
+	if err := roles.RemoveParent(roleID); err != nil {
+		return rest_errors.NewInternalServerError("Unable to remove role from its children", err)
 	}
 
 	if _, err := db.Engine.ID(roleID).Delete(&roles.Role{}); err != nil {
 		return rest_errors.NewInternalServerError("Unable to delete a role", err)
 	}
 
+	db.InvalidateAllPermissions()
+
 	return nil
 }
+
+// GetUsersInRole returns users that the role is assigned to them directly,
+// without their passwords, and count of the pages
+func GetUsersInRole(roleID, count, page int64) ([]users.User, int64, rest_errors.Error) {
+	if _, err := GetRoleByID(roleID); err != nil {
+		return nil, 0, err
+	}
+
+	all, err := users.GetByRole(roleID, count, page)
+	if err != nil {
+		return nil, 0, rest_errors.NewInternalServerError("Unable to find users of the role", err)
+	}
+
+	for i := range all {
+		// don't expose password
+		all[i].Password = ""
+	}
+
+	pages := int64(1)
+	if total, err := users.CountByRole(roleID); err == nil && total > 0 {
+		pages = (total + count - 1) / count
+	}
+
+	return all, pages, nil
+}
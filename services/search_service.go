@@ -1,65 +1,33 @@
 package services
 
 import (
-	"fmt"
-	"github.com/boof/umg/rbac/access"
-	"time"
-
 	"github.com/boof/umg/db"
 	"github.com/boof/umg/rbac/roles"
 	"github.com/boof/umg/rbac/users"
-	"github.com/boof/umg/settings"
-	"github.com/boof/umg/util/datetime"
+	"github.com/boof/umg/rest_errors"
+	"github.com/boof/umg/util/query"
 )
 
-func SearchUsers(text string) []*users.RoleUser {
+// SearchUsers returns the page of users that match the filter, most relevant
+// ones first, and count of the pages
+func SearchUsers(filter users.SearchFilter, count, page int64) ([]*users.RoleUser, int64, rest_errors.Error) {
 	res := make([]*users.RoleUser, 0)
 
-	if text == "" {
-		return res
+	found, total, err := users.Search(filter, count, page)
+	if err != nil {
+		return nil, 0, rest_errors.NewInternalServerError("Unable to search users", err)
 	}
 
-	var search []users.User
-	query := fmt.Sprintf("SELECT * FROM \"user\" "+
-		"WHERE username ILIKE '%%%s%%' OR name ILIKE '%%%s%%' or email ILIKE '%%%s%%'", text, text, text)
-
-	db.Engine.SQL(query).Limit(40).Find(&search)
-
-	for _, user := range search {
-		ru := &users.RoleUser{
-			ID:        user.ID,
-			Username:  user.Username,
-			Name:      user.Name,
-			Email:     user.Email,
-			Online:    db.IsOnline(user.ID),
-			CreatedAt: user.CreatedAt.Format("Jan 02, 2006"),
-			Roles:     make([]*roles.Role, 0),
-		}
-
-		if user.LastLogin.Before(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)) {
-			ru.LastLogin = "-"
-		} else {
-			ru.LastLogin = datetime.DurationString(user.LastLogin)
-		}
-
-		for _, r := range user.RoleIDs {
-			role, err := (&roles.Role{ID: r}).GetByID()
-			if err == nil {
-				ru.Roles = append(ru.Roles, role)
-			}
-		}
-
-		expire, err := (&access.Expire{UserID: user.ID}).GetByUserID()
-		if err == nil {
-			ru.ExpireAt = expire.ExpireAt.Format(settings.UserDTLayout)
-		} else {
-			ru.ExpireAt = "undefined"
-		}
+	for i := range found {
+		res = append(res, newRoleUser(&found[i]))
+	}
 
-		res = append(res, ru)
+	pages := int64(1)
+	if total > 0 {
+		pages = (total + count - 1) / count
 	}
 
-	return res
+	return res, pages, nil
 }
 
 func SearchRoles(text string) []map[string]interface{} {
@@ -70,8 +38,7 @@ func SearchRoles(text string) []map[string]interface{} {
 	}
 
 	var search []roles.Role
-	query := fmt.Sprintf("SELECT * FROM role WHERE name ILIKE '%%%s%%'", text)
-	db.Engine.SQL(query).Limit(20).Find(&search)
+	db.Engine.Where("name ILIKE ?", query.Contains(text)).Asc("id").Limit(20).Find(&search)
 
 	for _, role := range search {
 		out := make(map[string]interface{})
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"github.com/boof/umg/rbac/twofactor"
+	"github.com/boof/umg/rbac/users"
+	"github.com/boof/umg/rest_errors"
+	"github.com/boof/umg/settings"
+	"github.com/boof/umg/util/totp"
+)
+
+// EnrollTwoFactor creates a new TOTP secret for the user and returns the
+// secret and its provisioning URI, it should be verified to be enabled
+func EnrollTwoFactor(user *users.User) (string, string, rest_errors.Error) {
+	tf := &twofactor.TwoFactor{UserID: user.ID}
+	if err := tf.Enroll(); err != nil {
+		return "", "", rest_errors.NewNotAcceptableError(err.Error())
+	}
+
+	uri := totp.ProvisioningURI(settings.Conf.TwoFactor.Issuer, user.Username, tf.Secret)
+	return tf.Secret, uri, nil
+}
+
+// EnableTwoFactor verifies the first code of an enrollment, enables the
+// two-factor authentication and returns recovery codes
+func EnableTwoFactor(userID int64, code string) ([]string, rest_errors.Error) {
+	tf, err := (&twofactor.TwoFactor{UserID: userID}).GetByUserID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError("There is no two-factor enrollment")
+	}
+
+	if tf.Enabled {
+		return nil, rest_errors.NewNotAcceptableError("Two-factor authentication is already enabled")
+	}
+
+	if !tf.Verify(code) {
+		return nil, rest_errors.NewUnauthorizedError("Invalid code")
+	}
+
+	codes, err := tf.Enable()
+	if err != nil {
+		return nil, rest_errors.NewInternalServerError("Unable to enable two-factor authentication", err)
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor disables two-factor authentication of the user after
+// checking a TOTP or recovery code
+func DisableTwoFactor(userID int64, code string) rest_errors.Error {
+	if !VerifyTwoFactor(userID, code) {
+		return rest_errors.NewUnauthorizedError("Invalid code")
+	}
+
+	return ResetTwoFactor(userID)
+}
+
+// ResetTwoFactor removes two-factor authentication of the user without any check
+func ResetTwoFactor(userID int64) rest_errors.Error {
+	if err := (&twofactor.TwoFactor{UserID: userID}).RemoveByUserID(); err != nil {
+		return rest_errors.NewInternalServerError("Database error", err)
+	}
+
+	return nil
+}
+
+// VerifyTwoFactor checks a TOTP code or a recovery code of the user
+func VerifyTwoFactor(userID int64, code string) bool {
+	tf, err := (&twofactor.TwoFactor{UserID: userID}).GetByUserID()
+	if err != nil || !tf.Enabled {
+		return false
+	}
+
+	return tf.Verify(code) || tf.UseRecoveryCode(code)
+}
+
+// TwoFactorEnabled indicates that user has enabled two-factor authentication or not
+func TwoFactorEnabled(userID int64) bool {
+	return twofactor.IsEnabled(userID)
+}
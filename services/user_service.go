@@ -1,11 +1,13 @@
 package services
 
 import (
-	"fmt"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/boof/umg/db"
 	"github.com/boof/umg/email"
+	"github.com/boof/umg/logger"
 	"github.com/boof/umg/rbac/access"
 	"github.com/boof/umg/rbac/domains"
 	"github.com/boof/umg/rbac/policies"
@@ -29,6 +31,21 @@ func GetUserByID(userID int64) (*users.User, rest_errors.Error) {
 	return user, nil
 }
 
+// GetUsers returns users without their passwords and count of the pages
+func GetUsers(count, page int64, order, sortBy string) ([]users.User, int64, rest_errors.Error) {
+	all, err := users.GetAll(count, page, order, sortBy)
+	if err != nil {
+		return nil, 0, rest_errors.NewNotFoundError("Unable to find any user")
+	}
+
+	for i := range all {
+		// don't expose password
+		all[i].Password = ""
+	}
+
+	return all, userPages(count), nil
+}
+
 func GetSimpleUsers(count, page int64, order, sortBy string) ([]*users.SimpleUser, int64, rest_errors.Error) {
 	res := make([]*users.SimpleUser, 0)
 
@@ -37,14 +54,7 @@ func GetSimpleUsers(count, page int64, order, sortBy string) ([]*users.SimpleUse
 		return nil, 0, rest_errors.NewNotFoundError("Unable to find any user")
 	}
 
-	pages := int64(1)
-	if usersCount, err := users.Count(); err == nil {
-		pages = usersCount / count
-
-		if usersCount%count != 0 {
-			pages += 1
-		}
-	}
+	pages := userPages(count)
 
 	for _, user := range all {
 		user := &users.SimpleUser{
@@ -77,50 +87,48 @@ func GetRoleUsers(count, page int64, order, sortBy string) ([]*users.RoleUser, i
 		return res, 0, rest_errors.NewNotFoundError("Unable to find any user")
 	}
 
-	pages := int64(1)
-	if usersCount, err := users.Count(); err == nil {
-		pages = usersCount / count
+	pages := userPages(count)
 
-		if usersCount%count != 0 {
-			pages += 1
-		}
+	for i := range all {
+		res = append(res, newRoleUser(&all[i]))
 	}
 
-	for _, user := range all {
-		ru := &users.RoleUser{
-			ID:        user.ID,
-			Username:  user.Username,
-			Name:      user.Name,
-			Email:     user.Email,
-			Online:    db.IsOnline(user.ID),
-			CreatedAt: user.CreatedAt.Format("Jan 02, 2006"),
-			Roles:     make([]*roles.Role, 0),
-		}
+	return res, pages, nil
+}
 
-		if user.LastLogin.Before(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)) {
-			ru.LastLogin = "-"
-		} else {
-			ru.LastLogin = datetime.DurationString(user.LastLogin)
-		}
+// newRoleUser returns the user with its roles, online status and access expiry
+func newRoleUser(user *users.User) *users.RoleUser {
+	ru := &users.RoleUser{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Email:     user.Email,
+		Online:    db.IsOnline(user.ID),
+		CreatedAt: user.CreatedAt.Format("Jan 02, 2006"),
+		Roles:     make([]*roles.Role, 0),
+	}
 
-		for _, r := range user.RoleIDs {
-			role, err := (&roles.Role{ID: r}).GetByID()
-			if err == nil {
-				ru.Roles = append(ru.Roles, role)
-			}
-		}
+	if user.LastLogin.Before(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		ru.LastLogin = "-"
+	} else {
+		ru.LastLogin = datetime.DurationString(user.LastLogin)
+	}
 
-		expire, err := (&access.Expire{UserID: user.ID}).GetByUserID()
+	for _, r := range user.RoleIDs {
+		role, err := (&roles.Role{ID: r}).GetByID()
 		if err == nil {
-			ru.ExpireAt = expire.ExpireAt.Format(settings.UserDTLayout)
-		} else {
-			ru.ExpireAt = "undefined"
+			ru.Roles = append(ru.Roles, role)
 		}
+	}
 
-		res = append(res, ru)
+	expire, err := (&access.Expire{UserID: user.ID}).GetByUserID()
+	if err == nil {
+		ru.ExpireAt = expire.ExpireAt.Format(settings.UserDTLayout)
+	} else {
+		ru.ExpireAt = "undefined"
 	}
 
-	return res, pages, nil
+	return ru
 }
 
 func GetUserDomains(userID int64) ([]domains.Domain, rest_errors.Error) {
@@ -139,10 +147,14 @@ func GetUserDomains(userID int64) ([]domains.Domain, rest_errors.Error) {
 	}
 
 	res := make([]domains.Domain, 0)
-	for _, roleID := range user.RoleIDs {
+	for _, roleID := range roles.ResolveIDs(user.RoleIDs) {
 		all, err := policies.GetByRole(roleID)
 		if err == nil {
 			for _, p := range all {
+				if p.IsDeny() {
+					continue
+				}
+
 				if p.IsAllProductPolicy() || p.IsDomainPolicy() {
 					dom, err := (&domains.Domain{ID: p.DomainID}).GetByID()
 					if err == nil {
@@ -180,11 +192,32 @@ func GetUserProducts(userID, domainID int64) ([]products.Product, rest_errors.Er
 		return all, nil
 	}
 
+	dom, err := (&domains.Domain{ID: domainID}).GetByID()
+	if err != nil {
+		return nil, rest_errors.NewNotFoundError("Domain not found")
+	}
+
 	allProds := make([]products.Product, 0)
-	for _, roleID := range user.RoleIDs {
+	denied := make(map[int64]bool)
+	deniedAll := false
+
+	for _, roleID := range roles.ResolveIDs(user.RoleIDs) {
 		allPol, err := policies.GetByRole(roleID)
 		if err == nil {
 			for _, p := range allPol {
+				if p.IsDeny() {
+					// products that all of their actions are denied are hidden
+					if p.DomainID == domainID && p.HasAllActions() {
+						if p.IsAllProductPolicy() {
+							deniedAll = true
+						} else if p.IsProductPolicy() {
+							denied[p.ProductID] = true
+						}
+					}
+
+					continue
+				}
+
 				if p.IsAllProductPolicy() && p.DomainID == domainID {
 					_, err := (&domains.Domain{ID: p.DomainID}).GetByID()
 					if err == nil {
@@ -202,10 +235,25 @@ func GetUserProducts(userID, domainID int64) ([]products.Product, rest_errors.Er
 		}
 	}
 
-	return allProds, nil
+	if dom.Algorithm == domains.AllowOverrides {
+		return allProds, nil
+	}
+
+	res := make([]products.Product, 0)
+	for _, prod := range allProds {
+		if !deniedAll && !denied[prod.ID] {
+			res = append(res, prod)
+		}
+	}
+
+	return res, nil
 }
 
 func AddUser(user *users.User) rest_errors.Error {
+	if err := validatePassword(user, user.Password); err != nil {
+		return err
+	}
+
 	err := user.Save()
 	if err != nil {
 		return rest_errors.NewNotAcceptableError(err.Error())
@@ -214,9 +262,9 @@ func AddUser(user *users.User) rest_errors.Error {
 	if user.Email != "" {
 		err = email.SendWelcome(user.Name, user.Email)
 		if err != nil {
-			fmt.Printf("Error while sending welcom email: %v \n", err)
+			logger.Log.Error("error while sending welcome email", zap.Int64("user_id", user.ID), zap.Error(err))
 		} else {
-			fmt.Println("Welcom email sent")
+			logger.Log.Info("welcome email sent", zap.Int64("user_id", user.ID))
 		}
 	}
 
@@ -224,6 +272,10 @@ func AddUser(user *users.User) rest_errors.Error {
 }
 
 func AddUserWithRole(user *users.User, sendEmail bool, expireAt *time.Time) rest_errors.Error {
+	if err := validatePassword(user, user.Password); err != nil {
+		return err
+	}
+
 	err := user.SaveWithRoles()
 	if err != nil {
 		return rest_errors.NewNotAcceptableError(err.Error())
@@ -241,9 +293,9 @@ func AddUserWithRole(user *users.User, sendEmail bool, expireAt *time.Time) rest
 	if sendEmail && user.Email != "" {
 		err = email.SendWelcome(user.Name, user.Email)
 		if err != nil {
-			fmt.Printf("Error while sending welcome email: %v \n", err)
+			logger.Log.Error("error while sending welcome email", zap.Int64("user_id", user.ID), zap.Error(err))
 		} else {
-			fmt.Println("Welcome email sent")
+			logger.Log.Info("welcome email sent", zap.Int64("user_id", user.ID))
 		}
 	}
 
@@ -268,6 +320,20 @@ func DelUser(userID int64) rest_errors.Error {
 	return nil
 }
 
+// userPages returns count of the user pages with the given page size
+func userPages(count int64) int64 {
+	pages := int64(1)
+	if usersCount, err := users.Count(); err == nil {
+		pages = usersCount / count
+
+		if usersCount%count != 0 {
+			pages += 1
+		}
+	}
+
+	return pages
+}
+
 // GetPolices returns all policies that exists in user's roles
 func GetPolices(user *users.User) ([]map[string]interface{}, error) {
 	res := make([]map[string]interface{}, 0)
@@ -276,7 +342,7 @@ func GetPolices(user *users.User) ([]map[string]interface{}, error) {
 		return res, nil
 	}
 
-	for _, id := range user.RoleIDs {
+	for _, id := range roles.ResolveIDs(user.RoleIDs) {
 		rolePolicies, err := (&policies.Policy{RoleID: id}).GetNamedPolicies()
 		if err != nil {
 			continue
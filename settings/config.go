@@ -0,0 +1,503 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFile is the environment variable that points to the optional YAML config file
+const ConfigFile = "UMG_CONFIG"
+
+// Conf is the loaded configuration of the service
+var Conf *Config
+
+// Config is the typed configuration of the service, values are read from the
+// optional YAML file first and then overridden by the environment variables
+type Config struct {
+	// base url of the REST API
+	BaseURL string `yaml:"base_url" env:"BASE_URL"`
+
+	// listen addresses
+	APIAddr  string `yaml:"api_addr" env:"API_PORT"`
+	GRPCAddr string `yaml:"grpc_addr" env:"GRPC_ADDR"`
+
+	// ip addresses or CIDR ranges of the reverse proxies that X-Forwarded-For
+	// and X-Real-IP headers are accepted from
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+
+	// time that servers have to finish in-flight requests on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+
+	// portal page that reset password token is appended to it
+	ResetPasswordURL string `yaml:"reset_password_url" env:"RESET_PASSWORD_URL"`
+
+	GRPCTLS GRPCTLSConfig `yaml:"grpc_tls"`
+
+	JWT       JWTConfig       `yaml:"jwt"`
+	TwoFactor TwoFactorConfig `yaml:"two_factor"`
+	Lockout   LockoutConfig   `yaml:"lockout"`
+	Password  PasswordConfig  `yaml:"password"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Redis     RedisConfig     `yaml:"redis"`
+	Mail      MailConfig      `yaml:"mail"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+}
+
+const (
+	// admin RPCs need the JWT of an admin user
+	AdminAuthToken = "token"
+
+	// admin RPCs need a client certificate with one of the admin subjects
+	AdminAuthCertificate = "certificate"
+)
+
+type GRPCTLSConfig struct {
+	// server certificate, TLS is disabled when it's not set
+	CertFile string `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+
+	// clients should present a certificate signed by these CAs when it's set
+	ClientCAFile string `yaml:"client_ca_file" env:"GRPC_TLS_CLIENT_CA_FILE"`
+
+	// token or certificate, certificate mode checks subjects of client certificates
+	// for admin RPCs instead of the JWT of an admin user
+	AdminAuth     string   `yaml:"admin_auth" env:"GRPC_ADMIN_AUTH"`
+	AdminSubjects []string `yaml:"admin_subjects" env:"GRPC_ADMIN_SUBJECTS"`
+}
+
+type JWTConfig struct {
+	Secret          string        `yaml:"secret" env:"JWT_SECRET"`
+	Expiry          time.Duration `yaml:"expiry" env:"JWT_EXPIRY"`
+	RefreshExpiry   time.Duration `yaml:"refresh_expiry" env:"JWT_REFRESH_EXPIRY"`
+	RefreshAudience string        `yaml:"refresh_audience" env:"JWT_REFRESH_AUDIENCE"`
+
+	// challenge tokens are issued after password check when two-factor is enabled
+	ChallengeExpiry   time.Duration `yaml:"challenge_expiry" env:"JWT_CHALLENGE_EXPIRY"`
+	ChallengeAudience string        `yaml:"challenge_audience" env:"JWT_CHALLENGE_AUDIENCE"`
+
+	// name of each PEM file in the keys directory is its key id
+	KeysDir    string `yaml:"keys_dir" env:"JWT_KEYS_DIR"`
+	SigningKey string `yaml:"signing_key" env:"JWT_SIGNING_KEY"`
+}
+
+type TwoFactorConfig struct {
+	// issuer that authenticator apps show
+	Issuer string `yaml:"issuer" env:"TWO_FACTOR_ISSUER"`
+
+	// number of wrong codes that invalidates a challenge token
+	MaxAttempts int `yaml:"max_attempts" env:"TWO_FACTOR_MAX_ATTEMPTS"`
+}
+
+type LockoutConfig struct {
+	// failed logins before locking an account or an IP address
+	MaxAttempts   int `yaml:"max_attempts" env:"LOCKOUT_MAX_ATTEMPTS"`
+	MaxIPAttempts int `yaml:"max_ip_attempts" env:"LOCKOUT_MAX_IP_ATTEMPTS"`
+
+	// failed logins are forgotten after the window
+	Window time.Duration `yaml:"window" env:"LOCKOUT_WINDOW"`
+
+	// lock time doubles with each failure after the threshold, up to max duration
+	Duration    time.Duration `yaml:"duration" env:"LOCKOUT_DURATION"`
+	MaxDuration time.Duration `yaml:"max_duration" env:"LOCKOUT_MAX_DURATION"`
+}
+
+type PasswordConfig struct {
+	// hash algorithm of new passwords, argon2id or bcrypt
+	Algorithm         string `yaml:"algorithm" env:"PASSWORD_ALGORITHM"`
+	Argon2Memory      uint32 `yaml:"argon2_memory" env:"PASSWORD_ARGON2_MEMORY"`
+	Argon2Iterations  uint32 `yaml:"argon2_iterations" env:"PASSWORD_ARGON2_ITERATIONS"`
+	Argon2Parallelism uint8  `yaml:"argon2_parallelism" env:"PASSWORD_ARGON2_PARALLELISM"`
+	BcryptCost        int    `yaml:"bcrypt_cost" env:"PASSWORD_BCRYPT_COST"`
+
+	// password policy
+	MinLength     int  `yaml:"min_length" env:"PASSWORD_MIN_LENGTH"`
+	MaxLength     int  `yaml:"max_length" env:"PASSWORD_MAX_LENGTH"`
+	RequireUpper  bool `yaml:"require_upper" env:"PASSWORD_REQUIRE_UPPER"`
+	RequireLower  bool `yaml:"require_lower" env:"PASSWORD_REQUIRE_LOWER"`
+	RequireDigit  bool `yaml:"require_digit" env:"PASSWORD_REQUIRE_DIGIT"`
+	RequireSymbol bool `yaml:"require_symbol" env:"PASSWORD_REQUIRE_SYMBOL"`
+	RejectCommon  bool `yaml:"reject_common" env:"PASSWORD_REJECT_COMMON"`
+
+	// number of recent passwords that can't be reused
+	History int `yaml:"history" env:"PASSWORD_HISTORY"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	Name     string `yaml:"name" env:"POSTGRES_DB"`
+	User     string `yaml:"user" env:"POSTGRES_USER"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD"`
+	SSLMode  string `yaml:"ssl_mode" env:"DB_SSL_MODE"`
+}
+
+type CacheConfig struct {
+	// lifetime of compiled user permissions, changes of roles and policies
+	// invalidate them sooner
+	PermissionsTTL time.Duration `yaml:"permissions_ttl" env:"CACHE_PERMISSIONS_TTL"`
+}
+
+type RedisConfig struct {
+	Addr          string `yaml:"addr" env:"REDIS_ADDR"`
+	Password      string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB            int    `yaml:"db" env:"REDIS_DB"`
+	OnlineUsersDB int    `yaml:"online_users_db" env:"REDIS_ONLINE_USERS_DB"`
+	PermissionsDB int    `yaml:"permissions_db" env:"REDIS_PERMISSIONS_DB"`
+}
+
+type MailConfig struct {
+	Server   string `yaml:"server" env:"MAIL_SERVER"`
+	Port     int    `yaml:"port" env:"MAIL_SERVER_PORT"`
+	Address  string `yaml:"address" env:"SUPPORT_MAIL_ADDRESS"`
+	Password string `yaml:"password" env:"SUPPORT_MAIL_PASS"`
+}
+
+type LogConfig struct {
+	// minimum level of the logs, debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type TracingConfig struct {
+	// OTLP/HTTP endpoint of the collector like localhost:4318, spans aren't
+	// exported when it's not set
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	Insecure bool   `yaml:"insecure" env:"TRACING_INSECURE"`
+
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+
+	// fraction of the traces that are sampled, between 0 and 1
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+func init() {
+	conf, err := LoadConfig(os.Getenv(ConfigFile))
+	if err != nil {
+		log.Fatalf("unable to load config: %v", err)
+	}
+
+	Conf = conf
+}
+
+// DefaultConfig returns the configuration that is used when nothing is set
+func DefaultConfig() *Config {
+	return &Config{
+		BaseURL:          "/v1/umg/",
+		APIAddr:          ":4000",
+		GRPCAddr:         "0.0.0.0:50053",
+		ShutdownTimeout:  30 * time.Second,
+		ResetPasswordURL: "https://portal.edgecomenergy.ca/reset-password/",
+		GRPCTLS: GRPCTLSConfig{
+			AdminAuth: AdminAuthToken,
+		},
+		JWT: JWTConfig{
+			Secret: "OurSubjectiveJudgmentsWereBiased",
+			// Todo: decrease these values
+			Expiry:            2 * 24 * time.Hour,
+			RefreshExpiry:     7 * 24 * time.Hour,
+			RefreshAudience:   "https://api.edgecomenergy.ca/v1/umg/token",
+			ChallengeExpiry:   5 * time.Minute,
+			ChallengeAudience: "https://api.edgecomenergy.ca/v1/umg/2fa",
+		},
+		TwoFactor: TwoFactorConfig{
+			Issuer:      "Edgecom Energy",
+			MaxAttempts: 5,
+		},
+		Lockout: LockoutConfig{
+			MaxAttempts:   5,
+			MaxIPAttempts: 20,
+			Window:        time.Hour,
+			Duration:      time.Minute,
+			MaxDuration:   time.Hour,
+		},
+		Password: PasswordConfig{
+			Algorithm:         "argon2id",
+			Argon2Memory:      64 * 1024,
+			Argon2Iterations:  3,
+			Argon2Parallelism: 2,
+			BcryptCost:        12,
+			MinLength:         8,
+			MaxLength:         64,
+			RejectCommon:      true,
+			History:           5,
+		},
+		Cache: CacheConfig{
+			PermissionsTTL: 10 * time.Minute,
+		},
+		Database: DatabaseConfig{
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		Redis: RedisConfig{
+			Addr:          "redis:6379",
+			DB:            0,
+			OnlineUsersDB: 1,
+			PermissionsDB: 2,
+		},
+		Mail: MailConfig{
+			Port: 587,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			ServiceName: "umg",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// LoadConfig loads the config from the given YAML file and environment variables,
+// path can be empty
+func LoadConfig(path string) (*Config, error) {
+	conf := DefaultConfig()
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		if err := yaml.NewDecoder(file).Decode(conf); err != nil {
+			return nil, fmt.Errorf("invalid config file: %v", err)
+		}
+	}
+
+	if err := loadEnv(reflect.ValueOf(conf).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// Validate validates the config
+func (c *Config) Validate() error {
+	if !strings.HasPrefix(c.BaseURL, "/") || !strings.HasSuffix(c.BaseURL, "/") {
+		return errors.New("base url should start and end with '/'")
+	}
+
+	if c.APIAddr == "" || c.GRPCAddr == "" {
+		return errors.New("api and grpc addresses are required")
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		return errors.New("shutdown timeout should be positive")
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return fmt.Errorf("invalid trusted proxy %q", proxy)
+		}
+	}
+
+	tls := c.GRPCTLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		return errors.New("grpc tls needs both certificate and key files")
+	}
+
+	if tls.ClientCAFile != "" && tls.CertFile == "" {
+		return errors.New("grpc client certificates need server tls")
+	}
+
+	if tls.AdminAuth != AdminAuthToken && tls.AdminAuth != AdminAuthCertificate {
+		return errors.New("grpc admin auth should be token or certificate")
+	}
+
+	if tls.AdminAuth == AdminAuthCertificate && (tls.ClientCAFile == "" || len(tls.AdminSubjects) == 0) {
+		return errors.New("grpc certificate admin auth needs client ca and admin subjects")
+	}
+
+	if c.JWT.Secret == "" && c.JWT.KeysDir == "" {
+		return errors.New("either jwt secret or jwt keys directory is required")
+	}
+
+	if c.JWT.Expiry <= 0 || c.JWT.RefreshExpiry <= 0 {
+		return errors.New("jwt expiry times should be positive")
+	}
+
+	if c.JWT.RefreshExpiry < c.JWT.Expiry {
+		return errors.New("jwt refresh expiry can't be shorter than jwt expiry")
+	}
+
+	if c.JWT.RefreshAudience == "" {
+		return errors.New("jwt refresh audience is required")
+	}
+
+	if c.JWT.ChallengeExpiry <= 0 || c.JWT.ChallengeAudience == "" {
+		return errors.New("jwt challenge expiry and audience are required")
+	}
+
+	if c.JWT.ChallengeAudience == c.JWT.RefreshAudience {
+		return errors.New("jwt challenge and refresh audiences should be different")
+	}
+
+	if c.TwoFactor.Issuer == "" || c.TwoFactor.MaxAttempts < 1 {
+		return errors.New("two-factor issuer and max attempts are required")
+	}
+
+	if c.Lockout.MaxAttempts < 1 || c.Lockout.MaxIPAttempts < 1 {
+		return errors.New("lockout attempts should be positive")
+	}
+
+	if c.Lockout.Window <= 0 || c.Lockout.Duration <= 0 || c.Lockout.MaxDuration < c.Lockout.Duration {
+		return errors.New("invalid lockout durations")
+	}
+
+	switch c.Password.Algorithm {
+	case "argon2id":
+		if c.Password.Argon2Memory < 8*uint32(c.Password.Argon2Parallelism) ||
+			c.Password.Argon2Iterations < 1 || c.Password.Argon2Parallelism < 1 {
+			return errors.New("invalid argon2 parameters")
+		}
+	case "bcrypt":
+		if c.Password.BcryptCost < 10 || c.Password.BcryptCost > 31 {
+			return errors.New("bcrypt cost should be between 10 and 31")
+		}
+	default:
+		return errors.New("password algorithm should be argon2id or bcrypt")
+	}
+
+	if c.Password.MinLength < 1 || c.Password.MaxLength < c.Password.MinLength {
+		return errors.New("invalid password length limits")
+	}
+
+	if c.Password.History < 0 {
+		return errors.New("password history can't be negative")
+	}
+
+	if c.Cache.PermissionsTTL <= 0 {
+		return errors.New("permissions cache ttl should be positive")
+	}
+
+	if c.Redis.Addr == "" {
+		return errors.New("redis address is required")
+	}
+
+	if c.Redis.DB == c.Redis.OnlineUsersDB {
+		return errors.New("online users should use a separate redis database")
+	}
+
+	if c.Redis.PermissionsDB == c.Redis.DB || c.Redis.PermissionsDB == c.Redis.OnlineUsersDB {
+		return errors.New("permissions cache should use a separate redis database")
+	}
+
+	if c.Mail.Port < 1 || c.Mail.Port > 65535 {
+		return errors.New("invalid mail server port")
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return errors.New("log level should be debug, info, warn or error")
+	}
+
+	if c.Tracing.ServiceName == "" {
+		return errors.New("tracing service name is required")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return errors.New("tracing sample ratio should be between 0 and 1")
+	}
+
+	return nil
+}
+
+// loadEnv overrides struct fields that have an `env` tag with the value of
+// the environment variable, if it's set
+func loadEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		info := v.Type().Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := loadEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := info.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint8, reflect.Uint32:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		items := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
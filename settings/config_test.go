@@ -0,0 +1,70 @@
+package settings
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "umg-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "umg.yml")
+	content := "base_url: /v2/umg/\njwt:\n  expiry: 30m\nredis:\n  addr: localhost:6379\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("JWT_REFRESH_EXPIRY", "2h")
+	os.Setenv("DB_HOST", "staging-db")
+	defer os.Unsetenv("JWT_REFRESH_EXPIRY")
+	defer os.Unsetenv("DB_HOST")
+
+	conf, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unable to load config: %v", err)
+	}
+
+	if conf.BaseURL != "/v2/umg/" {
+		t.Errorf("expected base url from file, got %q", conf.BaseURL)
+	}
+
+	if conf.JWT.Expiry != 30*time.Minute || conf.JWT.RefreshExpiry != 2*time.Hour {
+		t.Errorf("unexpected jwt expiry: %v, %v", conf.JWT.Expiry, conf.JWT.RefreshExpiry)
+	}
+
+	if conf.Database.Host != "staging-db" || conf.Redis.Addr != "localhost:6379" {
+		t.Errorf("unexpected database or redis config: %+v, %+v", conf.Database, conf.Redis)
+	}
+
+	if conf.GRPCAddr != DefaultConfig().GRPCAddr {
+		t.Errorf("expected default grpc address, got %q", conf.GRPCAddr)
+	}
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	os.Setenv("JWT_EXPIRY", "-1m")
+	defer os.Unsetenv("JWT_EXPIRY")
+
+	if _, err := LoadConfig(""); err == nil {
+		t.Error("expected negative jwt expiry to be rejected")
+	}
+
+	os.Setenv("JWT_EXPIRY", "soon")
+	if _, err := LoadConfig(""); err == nil {
+		t.Error("expected invalid duration to be rejected")
+	}
+	os.Unsetenv("JWT_EXPIRY")
+	os.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,proxy.local")
+	defer os.Unsetenv("TRUSTED_PROXIES")
+
+	if _, err := LoadConfig(""); err == nil {
+		t.Error("expected invalid trusted proxy to be rejected")
+	}
+}
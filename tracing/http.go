@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Echo is a middleware that starts a server span for each request, trace
+// context of the client is read from the request headers
+func Echo(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		name := c.Path()
+		if name == "" {
+			name = "unknown"
+		}
+
+		ctx, span := Start(ctx, req.Method+" "+name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("umg", name, req)...),
+		)
+		defer span.End()
+
+		c.SetRequest(req.WithContext(ctx))
+
+		err := next(c)
+		if err != nil {
+			c.Error(err)
+		}
+
+		code := c.Response().Status
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(code))
+		if code >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package action
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+const (
+	// Separator separates namespaces of an action like `report:export:pdf`
+	Separator = ":"
+
+	// Wildcard as the last segment of a pattern matches the rest of the action
+	Wildcard = "*"
+)
+
+// Match indicates that the action matches the pattern or not. Segments are compared
+// case-insensitively with glob syntax, a `*` as the last segment of the pattern
+// matches one or more remaining segments of the action
+func Match(pattern, action string) bool {
+	patternSegs := strings.Split(strings.ToLower(pattern), Separator)
+	actionSegs := strings.Split(strings.ToLower(action), Separator)
+
+	for i, seg := range patternSegs {
+		if i >= len(actionSegs) {
+			return false
+		}
+
+		if seg == Wildcard && i == len(patternSegs)-1 {
+			return true
+		}
+
+		if ok, err := path.Match(seg, actionSegs[i]); err != nil || !ok {
+			return false
+		}
+	}
+
+	return len(patternSegs) == len(actionSegs)
+}
+
+// Overlap indicates that two patterns may match a common action. It's
+// conservative, glob segments on both sides are assumed to overlap
+func Overlap(a, b string) bool {
+	aSegs := strings.Split(strings.ToLower(a), Separator)
+	bSegs := strings.Split(strings.ToLower(b), Separator)
+
+	for i := 0; ; i++ {
+		if i == len(aSegs) || i == len(bSegs) {
+			return i == len(aSegs) && i == len(bSegs)
+		}
+
+		if (aSegs[i] == Wildcard && i == len(aSegs)-1) || (bSegs[i] == Wildcard && i == len(bSegs)-1) {
+			return true
+		}
+
+		if !segmentsOverlap(aSegs[i], bSegs[i]) {
+			return false
+		}
+	}
+}
+
+func segmentsOverlap(a, b string) bool {
+	if IsPattern(a) && IsPattern(b) {
+		return true
+	}
+
+	if ok, err := path.Match(a, b); err == nil && ok {
+		return true
+	}
+
+	ok, err := path.Match(b, a)
+	return err == nil && ok
+}
+
+// ValidatePattern checks the syntax of an action pattern
+func ValidatePattern(pattern string) error {
+	for _, seg := range strings.Split(pattern, Separator) {
+		if seg == "" {
+			return errors.New("action segments can't be empty")
+		}
+
+		if _, err := path.Match(seg, ""); err != nil {
+			return errors.New("invalid action pattern")
+		}
+	}
+
+	return nil
+}
+
+// Validate checks an action that is registered in the catalogue of a domain,
+// actions can't have glob characters
+func Validate(action string) error {
+	if IsPattern(action) {
+		return errors.New("action can't have glob characters")
+	}
+
+	return ValidatePattern(action)
+}
+
+// IsPattern indicates that the given action has glob characters or not
+func IsPattern(action string) bool {
+	return strings.ContainsAny(action, "*?[]\\")
+}
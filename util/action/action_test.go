@@ -0,0 +1,86 @@
+package action
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		action  string
+		match   bool
+	}{
+		{"*", "read", true},
+		{"*", "meter:read", true},
+		{"read", "read", true},
+		{"Read", "read", true},
+		{"read", "write", false},
+		{"meter:read", "meter:read", true},
+		{"meter:read", "meter:write", false},
+		{"meter:*", "meter:read", true},
+		{"meter:*", "meter:read:all", true},
+		{"meter:*", "meter", false},
+		{"meter:*", "report:read", false},
+		{"report:export:*", "report:export:pdf", true},
+		{"report:export:*", "report:read", false},
+		{"*:read", "meter:read", true},
+		{"*:read", "meter:read:all", false},
+		{"meter:re*", "meter:read", true},
+		{"meter:re*", "meter:write", false},
+		{"meter:read", "meter:read:all", false},
+	}
+
+	for _, c := range cases {
+		if got := Match(c.pattern, c.action); got != c.match {
+			t.Errorf("Match(%q, %q) = %v, expected %v", c.pattern, c.action, got, c.match)
+		}
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	cases := []struct {
+		a, b    string
+		overlap bool
+	}{
+		{"meter:*", "meter:write", true},
+		{"meter:write", "meter:*", true},
+		{"*", "meter:write", true},
+		{"meter:*", "report:*", false},
+		{"meter:*", "meter", false},
+		{"meter:re*", "meter:write", false},
+		{"meter:re*", "meter:*", true},
+		{"m*:read", "meter:r*", true},
+		{"meter:read", "meter:write", false},
+		{"meter:read", "meter:read:all", false},
+		{"*:read", "meter:read:all", false},
+		{"report:export:*", "report:*", true},
+	}
+
+	for _, c := range cases {
+		if got := Overlap(c.a, c.b); got != c.overlap {
+			t.Errorf("Overlap(%q, %q) = %v, expected %v", c.a, c.b, got, c.overlap)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"read", "meter:read", "report:export:pdf"}
+	for _, act := range valid {
+		if err := Validate(act); err != nil {
+			t.Errorf("expected %q to be valid: %v", act, err)
+		}
+	}
+
+	invalid := []string{"", "meter:", ":read", "meter::read", "meter:*", "re[ad"}
+	for _, act := range invalid {
+		if err := Validate(act); err == nil {
+			t.Errorf("expected %q to be invalid", act)
+		}
+	}
+
+	if err := ValidatePattern("meter:*"); err != nil {
+		t.Errorf("expected meter:* to be a valid pattern: %v", err)
+	}
+
+	if err := ValidatePattern("meter:[a-"); err == nil {
+		t.Error("expected malformed pattern to be rejected")
+	}
+}
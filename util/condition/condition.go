@@ -0,0 +1,208 @@
+package condition
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Context is the request that conditions are evaluated against
+type Context struct {
+	// local time of the request, `time` and `weekday` variables are read from it
+	Time time.Time
+
+	// ip address of the client
+	IP string
+
+	// attributes of the request, they are accessed by `attr.<name>`
+	Attributes map[string]string
+}
+
+const attrPrefix = "attr."
+
+// parsed conditions by their source
+var cache sync.Map
+
+// Validate checks the syntax of a condition
+func Validate(src string) error {
+	_, err := parse(src)
+	return err
+}
+
+// Evaluate evaluates the condition against the context, empty condition
+// is always true. It returns an error when the context doesn't have
+// a variable of the condition
+func Evaluate(src string, ctx *Context) (bool, error) {
+	if strings.TrimSpace(src) == "" {
+		return true, nil
+	}
+
+	if ctx == nil {
+		ctx = &Context{}
+	}
+
+	var n node
+	if cached, ok := cache.Load(src); ok {
+		n = cached.(node)
+	} else {
+		parsed, err := parse(src)
+		if err != nil {
+			return false, err
+		}
+
+		cache.Store(src, parsed)
+		n = parsed
+	}
+
+	return n.eval(ctx)
+}
+
+type node interface {
+	eval(ctx *Context) (bool, error)
+}
+
+type operand interface {
+	value(ctx *Context) (string, error)
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n *logical) eval(ctx *Context) (bool, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// short circuit
+	if (n.op == "&&" && !left) || (n.op == "||" && left) {
+		return left, nil
+	}
+
+	return n.right.eval(ctx)
+}
+
+type not struct {
+	operand node
+}
+
+func (n *not) eval(ctx *Context) (bool, error) {
+	res, err := n.operand.eval(ctx)
+	return !res, err
+}
+
+type literal struct {
+	str  string
+	list []string
+}
+
+func (l *literal) value(ctx *Context) (string, error) {
+	return l.str, nil
+}
+
+func (l *literal) items() []string {
+	if l.list != nil {
+		return l.list
+	}
+
+	return []string{l.str}
+}
+
+type variable struct {
+	name string
+}
+
+func isVariable(name string) bool {
+	return name == "time" || name == "weekday" || name == "ip" ||
+		(strings.HasPrefix(name, attrPrefix) && len(name) > len(attrPrefix))
+}
+
+func (v *variable) value(ctx *Context) (string, error) {
+	switch v.name {
+	case "time":
+		if ctx.Time.IsZero() {
+			return "", errors.New("request time is unknown")
+		}
+		return ctx.Time.Format("15:04"), nil
+
+	case "weekday":
+		if ctx.Time.IsZero() {
+			return "", errors.New("request time is unknown")
+		}
+		return strings.ToLower(ctx.Time.Weekday().String()[:3]), nil
+
+	case "ip":
+		if ctx.IP == "" {
+			return "", errors.New("request ip is unknown")
+		}
+		return ctx.IP, nil
+	}
+
+	attr, ok := ctx.Attributes[strings.TrimPrefix(v.name, attrPrefix)]
+	if !ok {
+		return "", errors.New("request doesn't have " + v.name)
+	}
+
+	return attr, nil
+}
+
+type comparison struct {
+	op          string
+	left, right operand
+}
+
+func (c *comparison) eval(ctx *Context) (bool, error) {
+	left, err := c.left.value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if c.op == "in" {
+		for _, item := range c.right.(*literal).items() {
+			if contains(item, left) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	right, err := c.right.value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	switch c.op {
+	case "==":
+		return strings.EqualFold(left, right), nil
+	case "!=":
+		return !strings.EqualFold(left, right), nil
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	case ">=":
+		return left >= right, nil
+	}
+
+	return false, errors.New("invalid operator " + c.op)
+}
+
+// contains indicates that the value is equal to the item or it's an ip
+// address in the item's CIDR range
+func contains(item, value string) bool {
+	if strings.Contains(item, "/") {
+		_, network, err := net.ParseCIDR(item)
+		ip := net.ParseIP(value)
+
+		return err == nil && ip != nil && network.Contains(ip)
+	}
+
+	return strings.EqualFold(item, value)
+}
@@ -0,0 +1,83 @@
+package condition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	// Wednesday
+	ctx := &Context{
+		Time:       time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC),
+		IP:         "10.1.2.3",
+		Attributes: map[string]string{"department": "Operations", "site": "toronto"},
+	}
+
+	businessHours := `time >= "09:00" && time < "17:00" && weekday in ["mon", "tue", "wed", "thu", "fri"]`
+
+	cases := []struct {
+		condition string
+		result    bool
+	}{
+		{"", true},
+		{businessHours, true},
+		{businessHours + ` && ip in ["10.0.0.0/8", "192.168.1.0/24"]`, true},
+		{`ip in "192.168.0.0/16"`, false},
+		{`ip == "10.1.2.3"`, true},
+		{`attr.department == "operations"`, true},
+		{`attr.department != "operations"`, false},
+		{`attr.site in ["montreal", "ottawa"] || attr.department == "operations"`, true},
+		{`!(weekday in ["sat", "sun"])`, true},
+		{`time < "09:00" || time >= "17:00"`, false},
+	}
+
+	for _, c := range cases {
+		res, err := Evaluate(c.condition, ctx)
+		if err != nil {
+			t.Errorf("unable to evaluate %q: %v", c.condition, err)
+		} else if res != c.result {
+			t.Errorf("Evaluate(%q) = %v, expected %v", c.condition, res, c.result)
+		}
+	}
+
+	weekend := &Context{Time: time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)}
+	if res, _ := Evaluate(businessHours, weekend); res {
+		t.Error("expected business hours condition to be false on saturday")
+	}
+}
+
+func TestEvaluateMissingVariable(t *testing.T) {
+	if _, err := Evaluate(`attr.department == "ops"`, &Context{}); err == nil {
+		t.Error("expected missing attribute to fail")
+	}
+
+	if _, err := Evaluate(`ip in "10.0.0.0/8"`, nil); err == nil {
+		t.Error("expected missing ip to fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	invalid := []string{
+		`time >= "09:00" &&`,
+		`hour >= "09:00"`,
+		`ip = "10.0.0.1"`,
+		`weekday == ["mon"]`,
+		`"mon" in weekday`,
+		`(ip == "10.0.0.1"`,
+		`attr. == "x"`,
+		`time >= "09:00`,
+		`time >= "9:00"`,
+		`time < "25:00"`,
+		`time > "noon"`,
+		`"09:60" <= time`,
+		`time in ["09:00", "5pm"]`,
+		`["a"] in attr.x`,
+		`["a", "b"] == attr.x`,
+	}
+
+	for _, src := range invalid {
+		if err := Validate(src); err == nil {
+			t.Errorf("expected %q to be invalid", src)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package condition
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lex splits the expression into tokens
+func lex(src string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '"' || c == '\'':
+			end := strings.IndexRune(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokString, src[i+1 : i+1+end], i})
+			i += end + 2
+
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{tokLBracket, "[", i})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{tokRBracket, "]", i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+
+		case strings.ContainsRune("=!<>&|", c):
+			op := string(c)
+			if i+1 < len(src) && isTwoCharOp(src[i:i+2]) {
+				op = src[i : i+2]
+			}
+			if op == "=" || op == "&" || op == "|" {
+				return nil, fmt.Errorf("invalid operator %q at %d", op, i)
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+
+		case isIdentRune(c):
+			start := i
+			for i < len(src) && isIdentRune(rune(src[i])) {
+				i++
+			}
+
+			text := src[start:i]
+			if text == "in" {
+				tokens = append(tokens, token{tokOp, text, start})
+			} else {
+				tokens = append(tokens, token{tokIdent, text, start})
+			}
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+func isTwoCharOp(op string) bool {
+	switch op {
+	case "==", "!=", "<=", ">=", "&&", "||":
+		return true
+	}
+
+	return false
+}
+
+func isIdentRune(c rune) bool {
+	return c == '_' || c == '.' || c == '-' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse builds the syntax tree of the expression
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOp && p.peek().text == "||" {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logical{op: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokOp && p.peek().text == "&&" {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logical{op: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.peek()
+
+	if tok.kind == tokOp && tok.text == "!" {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &not{operand: operand}, nil
+	}
+
+	if tok.kind == tokLParen {
+		p.next()
+
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok := p.next(); tok.kind != tokRParen {
+			return nil, fmt.Errorf("expected ) at %d", tok.pos)
+		}
+
+		return n, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+	if tok.kind != tokOp || !isComparison(tok.text) {
+		return nil, fmt.Errorf("expected comparison at %d", tok.pos)
+	}
+
+	if l, ok := left.(*literal); ok && l.list != nil {
+		return nil, fmt.Errorf("lists can only be used on the right side of in at %d", tok.pos)
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.text == "in" {
+		if _, ok := right.(*literal); !ok {
+			return nil, fmt.Errorf("right side of in should be a string or a list at %d", tok.pos)
+		}
+	} else if l, ok := right.(*literal); ok && l.list != nil {
+		return nil, fmt.Errorf("lists can only be used with in at %d", tok.pos)
+	}
+
+	// time is compared as a string, so literals should be zero padded
+	if !validTimeOperands(left, right) || !validTimeOperands(right, left) {
+		return nil, fmt.Errorf("time should be compared with HH:MM at %d", tok.pos)
+	}
+
+	return &comparison{op: tok.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokString:
+		return &literal{str: tok.text}, nil
+
+	case tokIdent:
+		if !isVariable(tok.text) {
+			return nil, fmt.Errorf("unknown variable %s at %d", tok.text, tok.pos)
+		}
+
+		return &variable{name: tok.text}, nil
+
+	case tokLBracket:
+		list := make([]string, 0)
+		for {
+			item := p.next()
+			if item.kind != tokString {
+				return nil, fmt.Errorf("expected string at %d", item.pos)
+			}
+			list = append(list, item.text)
+
+			sep := p.next()
+			if sep.kind == tokRBracket {
+				break
+			} else if sep.kind != tokComma {
+				return nil, fmt.Errorf("expected , or ] at %d", sep.pos)
+			}
+		}
+
+		return &literal{list: list}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+}
+
+// validTimeOperands checks literals that are compared with the time variable
+func validTimeOperands(v, other operand) bool {
+	if v, ok := v.(*variable); !ok || v.name != "time" {
+		return true
+	}
+
+	l, ok := other.(*literal)
+	if !ok {
+		return true
+	}
+
+	for _, item := range l.items() {
+		if _, err := time.Parse("15:04", item); err != nil || len(item) != len("15:04") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=", "in":
+		return true
+	}
+
+	return false
+}
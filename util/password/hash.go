@@ -0,0 +1,151 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrevicenzi/unchained"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/boof/umg/settings"
+)
+
+const (
+	// Argon2id hashes are saved in PHC string format:
+	// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+	Argon2id = "argon2id"
+
+	// Bcrypt hashes are saved in modular crypt format: $2a$12$<salt and hash>
+	Bcrypt = "bcrypt"
+
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// HashPassword hashes the password with the configured algorithm and a random salt
+func HashPassword(pass string) (string, error) {
+	conf := settings.Conf.Password
+
+	switch conf.Algorithm {
+	case Bcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(pass), conf.BcryptCost)
+		return string(hash), err
+	case Argon2id:
+		return hashArgon2id(pass, configuredArgon2Params())
+	default:
+		return "", fmt.Errorf("unsupported password hash algorithm %q", conf.Algorithm)
+	}
+}
+
+// IsValidPass checks the password against argon2id, bcrypt and legacy Django style hashes
+func IsValidPass(pass, hash string) bool {
+	switch {
+	case strings.HasPrefix(hash, "$"+Argon2id+"$"):
+		return checkArgon2id(pass, hash)
+	case isBcrypt(hash):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass)) == nil
+	default:
+		valid, _ := unchained.CheckPassword(pass, hash)
+		return valid
+	}
+}
+
+// NeedsRehash indicates that the hash is not made by the configured algorithm
+// and cost, so it should be replaced on the next successful login
+func NeedsRehash(hash string) bool {
+	conf := settings.Conf.Password
+
+	switch conf.Algorithm {
+	case Bcrypt:
+		if !isBcrypt(hash) {
+			return true
+		}
+
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != conf.BcryptCost
+	case Argon2id:
+		params, _, _, err := decodeArgon2id(hash)
+		return err != nil || params != configuredArgon2Params()
+	default:
+		return false
+	}
+}
+
+func configuredArgon2Params() argon2Params {
+	conf := settings.Conf.Password
+
+	return argon2Params{
+		memory:      conf.Argon2Memory,
+		iterations:  conf.Argon2Iterations,
+		parallelism: conf.Argon2Parallelism,
+	}
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func hashArgon2id(pass string, params argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(pass), salt, params.iterations, params.memory, params.parallelism, argon2KeyLen)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", Argon2id, argon2.Version,
+		params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func checkArgon2id(pass, hash string) bool {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(pass), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+func decodeArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return params, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errors.New("unsupported argon2 version")
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, err
+	}
+
+	return params, salt, key, nil
+}
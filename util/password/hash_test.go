@@ -0,0 +1,68 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrevicenzi/unchained"
+
+	"github.com/boof/umg/settings"
+)
+
+func TestHashPassword(t *testing.T) {
+	old := settings.Conf.Password
+	defer func() { settings.Conf.Password = old }()
+
+	// keep tests fast
+	settings.Conf.Password.Argon2Memory = 1024
+	settings.Conf.Password.Argon2Iterations = 1
+	settings.Conf.Password.BcryptCost = 10
+
+	for _, algorithm := range []string{Argon2id, Bcrypt} {
+		settings.Conf.Password.Algorithm = algorithm
+
+		first, err := HashPassword("Correct-Horse-9")
+		if err != nil {
+			t.Fatalf("%s: unable to hash password: %v", algorithm, err)
+		}
+
+		second, _ := HashPassword("Correct-Horse-9")
+		if first == second {
+			t.Errorf("%s: expected random salt for each hash", algorithm)
+		}
+
+		if !IsValidPass("Correct-Horse-9", first) || IsValidPass("correct-horse-9", first) {
+			t.Errorf("%s: invalid password verification", algorithm)
+		}
+
+		if NeedsRehash(first) {
+			t.Errorf("%s: expected fresh hash to be up to date", algorithm)
+		}
+	}
+
+	settings.Conf.Password.Algorithm = Argon2id
+	hash, _ := HashPassword("Correct-Horse-9")
+	settings.Conf.Password.Argon2Iterations = 2
+	if !NeedsRehash(hash) {
+		t.Error("expected hash with old cost to need rehash")
+	}
+}
+
+func TestLegacyHash(t *testing.T) {
+	legacy, err := unchained.MakePassword("pass", "19thTitleIsLoading#", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.HasPrefix(legacy, "$") {
+		t.Fatalf("unexpected legacy hash format: %s", legacy)
+	}
+
+	if !IsValidPass("pass", legacy) || IsValidPass("wrong", legacy) {
+		t.Error("expected legacy hashes to be verified")
+	}
+
+	if !NeedsRehash(legacy) {
+		t.Error("expected legacy hash to need rehash")
+	}
+}
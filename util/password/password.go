@@ -4,10 +4,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/alexandrevicenzi/unchained"
 	"github.com/go-playground/validator/v10"
-
-	"github.com/boof/umg/settings"
 )
 
 var (
@@ -19,15 +16,6 @@ func init() {
 	validate = validator.New()
 }
 
-func HashPassword(pass string) (string, error) {
-	return unchained.MakePassword(pass, settings.PasswordSalt, "default")
-}
-
-func IsValidPass(pass, hash string) bool {
-	valid, _ := unchained.CheckPassword(pass, hash)
-	return valid
-}
-
 func ValidateUsername(username string) error {
 	if errs := validate.Var(username, "min=1"); errs != nil {
 		return errors.New("username must contain at least one character")
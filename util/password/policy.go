@@ -0,0 +1,123 @@
+package password
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/boof/umg/settings"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// list of common passwords that can't be used
+var commonPasswords map[string]bool
+
+func init() {
+	commonPasswords = make(map[string]bool)
+
+	for _, line := range strings.Split(commonPasswordsFile, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			commonPasswords[strings.ToLower(line)] = true
+		}
+	}
+}
+
+// Violation is a password policy rule that a password doesn't satisfy
+type Violation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PolicyError is returned when a password violates the password policy
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// Causes returns violations as rest error causes
+func (e *PolicyError) Causes() []interface{} {
+	causes := make([]interface{}, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		causes = append(causes, v)
+	}
+
+	return causes
+}
+
+// ValidatePolicy checks the password against the configured password policy
+func ValidatePolicy(pass, username string) *PolicyError {
+	conf := settings.Conf.Password
+	violations := make([]Violation, 0)
+
+	length := len([]rune(pass))
+	if length < conf.MinLength {
+		violations = append(violations, Violation{"min_length",
+			fmt.Sprintf("password should have at least %d characters", conf.MinLength)})
+	}
+
+	if length > conf.MaxLength {
+		violations = append(violations, Violation{"max_length",
+			fmt.Sprintf("password should have at most %d characters", conf.MaxLength)})
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, ch := range pass {
+		switch {
+		case unicode.IsUpper(ch):
+			upper = true
+		case unicode.IsLower(ch):
+			lower = true
+		case unicode.IsDigit(ch):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	if conf.RequireUpper && !upper {
+		violations = append(violations, Violation{"upper", "password should contain an uppercase letter"})
+	}
+
+	if conf.RequireLower && !lower {
+		violations = append(violations, Violation{"lower", "password should contain a lowercase letter"})
+	}
+
+	if conf.RequireDigit && !digit {
+		violations = append(violations, Violation{"digit", "password should contain a digit"})
+	}
+
+	if conf.RequireSymbol && !symbol {
+		violations = append(violations, Violation{"symbol", "password should contain a symbol"})
+	}
+
+	if username != "" && strings.EqualFold(pass, username) {
+		violations = append(violations, Violation{"username", "password can't be the same as the username"})
+	}
+
+	if conf.RejectCommon && commonPasswords[strings.ToLower(pass)] {
+		violations = append(violations, Violation{"common", "password is too common"})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &PolicyError{Violations: violations}
+}
+
+// ReusedViolation is returned when a password is one of the recent passwords of a user
+func ReusedViolation() *PolicyError {
+	return &PolicyError{Violations: []Violation{{"reused",
+		fmt.Sprintf("password can't be one of the last %d passwords", settings.Conf.Password.History)}}}
+}
@@ -0,0 +1,60 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/boof/umg/settings"
+)
+
+func violationCodes(err *PolicyError) map[string]bool {
+	codes := make(map[string]bool)
+	if err != nil {
+		for _, v := range err.Violations {
+			codes[v.Code] = true
+		}
+	}
+
+	return codes
+}
+
+func TestValidatePolicy(t *testing.T) {
+	old := settings.Conf.Password
+	defer func() { settings.Conf.Password = old }()
+
+	settings.Conf.Password.MinLength = 10
+	settings.Conf.Password.RequireUpper = true
+	settings.Conf.Password.RequireDigit = true
+	settings.Conf.Password.RequireSymbol = true
+	settings.Conf.Password.RejectCommon = true
+
+	cases := []struct {
+		pass     string
+		username string
+		expected []string
+	}{
+		{"Correct-Horse-9", "jsmith", nil},
+		{"short", "jsmith", []string{"min_length", "upper", "digit", "symbol"}},
+		{"password123", "jsmith", []string{"upper", "symbol", "common"}},
+		{"JSmith-2020!", "jsmith-2020!", []string{"username"}},
+	}
+
+	for _, c := range cases {
+		codes := violationCodes(ValidatePolicy(c.pass, c.username))
+		if len(codes) != len(c.expected) {
+			t.Errorf("%q: expected %v, got %v", c.pass, c.expected, codes)
+			continue
+		}
+
+		for _, code := range c.expected {
+			if !codes[code] {
+				t.Errorf("%q: expected %s violation, got %v", c.pass, code, codes)
+			}
+		}
+	}
+}
+
+func TestCommonPasswordsLoaded(t *testing.T) {
+	if !commonPasswords["qwerty"] || !commonPasswords["letmein"] {
+		t.Error("expected embedded common passwords to be loaded")
+	}
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	Asc  = "ASC"
+	Desc = "DESC"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Direction returns the SQL direction of the order, ascending unless it's desc
+func Direction(order string) string {
+	if strings.ToLower(order) == "desc" {
+		return Desc
+	}
+
+	return Asc
+}
+
+// OrderBy returns the ORDER BY clause of the sort key and order, only the
+// expressions of the allowed keys are used and other keys are rejected
+func OrderBy(sortBy, order string, allowed map[string]string) (string, error) {
+	expr, ok := allowed[sortBy]
+	if !ok {
+		return "", errors.New("invalid sort field")
+	}
+
+	return expr + " " + Direction(order), nil
+}
+
+// Contains returns a LIKE pattern that matches the text anywhere, wildcards
+// in the text match only themselves
+func Contains(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
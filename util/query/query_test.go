@@ -0,0 +1,79 @@
+package query
+
+import (
+	"strings"
+	"testing"
+)
+
+// common payloads of SQL injection
+var payloads = []string{
+	`' OR '1'='1`,
+	`'; DROP TABLE "user"; --`,
+	`id; DROP TABLE role`,
+	`name DESC, (SELECT pg_sleep(10))`,
+	`1) UNION SELECT * FROM "user" --`,
+	`%' AND 1=1 --`,
+	`desc; DELETE FROM role`,
+	`\'; SELECT 1; --`,
+}
+
+var columns = map[string]string{
+	"id":   "id",
+	"name": "LOWER(name)",
+}
+
+func TestOrderBy(t *testing.T) {
+	cases := []struct {
+		sortBy, order, expected string
+	}{
+		{"id", "asc", "id ASC"},
+		{"id", "DESC", "id DESC"},
+		{"name", "desc", "LOWER(name) DESC"},
+		{"name", "", "LOWER(name) ASC"},
+	}
+
+	for _, c := range cases {
+		res, err := OrderBy(c.sortBy, c.order, columns)
+		if err != nil || res != c.expected {
+			t.Errorf("OrderBy(%q, %q) = %q, %v, expected %q", c.sortBy, c.order, res, err, c.expected)
+		}
+	}
+}
+
+func TestOrderByInjection(t *testing.T) {
+	for _, payload := range payloads {
+		if res, err := OrderBy(payload, "asc", columns); err == nil {
+			t.Errorf("expected sort %q to be rejected, got %q", payload, res)
+		}
+
+		res, err := OrderBy("id", payload, columns)
+		if err != nil || (res != "id ASC" && res != "id DESC") {
+			t.Errorf("order %q made %q, %v", payload, res, err)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	cases := map[string]string{
+		"john":     "%john%",
+		"50%":      `%50\%%`,
+		"a_b":      `%a\_b%`,
+		`c:\temp`:  `%c:\\temp%`,
+		"":         "%%",
+		`' OR 1=1`: `%' OR 1=1%`,
+	}
+
+	for text, expected := range cases {
+		if res := Contains(text); res != expected {
+			t.Errorf("Contains(%q) = %q, expected %q", text, res, expected)
+		}
+	}
+
+	for _, payload := range payloads {
+		pattern := Contains(payload)
+		inner := pattern[1 : len(pattern)-1]
+		if strings.Count(inner, "%") != strings.Count(inner, `\%`) {
+			t.Errorf("Contains(%q) = %q has unescaped wildcards", payload, pattern)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/boof/umg/settings"
+)
+
+// ClientIP returns the ip address of the client. Forwarded headers are only
+// used when the connection comes from one of the trusted proxies, otherwise
+// the remote address of the connection is returned
+func ClientIP(c echo.Context) string {
+	return clientIP(c.Request(), settings.Conf.TrustedProxies)
+}
+
+func clientIP(req *http.Request, proxies []string) string {
+	remote, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remote = req.RemoteAddr
+	}
+
+	trusted := trustedNetworks(proxies)
+	if !isTrusted(remote, trusted) {
+		return remote
+	}
+
+	// the right most address that isn't a trusted proxy is the client
+	forwarded := strings.Split(req.Header.Get(echo.HeaderXForwardedFor), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(ip) == nil {
+			break
+		}
+
+		if !isTrusted(ip, trusted) {
+			return ip
+		}
+	}
+
+	if ip := strings.TrimSpace(req.Header.Get(echo.HeaderXRealIP)); net.ParseIP(ip) != nil {
+		return ip
+	}
+
+	return remote
+}
+
+// trustedNetworks parses the trusted proxies, an ip address is a network of its own
+func trustedNetworks(proxies []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil {
+				bits := 8 * len(ip)
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 32
+				}
+
+				networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			}
+
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
+}
+
+func isTrusted(ip string, networks []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package request
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies := []string{"10.0.0.0/8", "192.168.1.1"}
+
+	tests := []struct {
+		remote    string
+		forwarded string
+		realIP    string
+		expected  string
+	}{
+		// untrusted connections can't spoof their address
+		{"203.0.113.7:5000", "1.2.3.4", "1.2.3.5", "203.0.113.7"},
+		{"203.0.113.7:5000", "", "", "203.0.113.7"},
+
+		// trusted proxies
+		{"10.1.2.3:5000", "198.51.100.4", "", "198.51.100.4"},
+		{"192.168.1.1:5000", "198.51.100.4", "", "198.51.100.4"},
+		{"10.1.2.3:5000", "1.2.3.4, 198.51.100.4, 10.9.9.9", "", "198.51.100.4"},
+		{"10.1.2.3:5000", "", "198.51.100.4", "198.51.100.4"},
+		{"10.1.2.3:5000", "", "", "10.1.2.3"},
+		{"10.1.2.3:5000", "not-an-ip", "", "10.1.2.3"},
+		{"192.168.1.2:5000", "198.51.100.4", "", "192.168.1.2"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remote
+		if test.forwarded != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, test.forwarded)
+		}
+		if test.realIP != "" {
+			req.Header.Set(echo.HeaderXRealIP, test.realIP)
+		}
+
+		if ip := clientIP(req, proxies); ip != test.expected {
+			t.Errorf("remote %s, forwarded %q, real ip %q: expected %s, got %s",
+				test.remote, test.forwarded, test.realIP, test.expected, ip)
+		}
+	}
+}